@@ -0,0 +1,12 @@
+package authproviders
+
+type KeycloakConfig struct {
+	DisplayNameField   string `json:"displayNameField,omitempty" yaml:"displayNameField,omitempty"`
+	EntityID           string `json:"entityID,omitempty" yaml:"entityID,omitempty"`
+	GroupsField        string `json:"groupsField,omitempty" yaml:"groupsField,omitempty"`
+	IdpMetadataContent string `json:"idpMetadataContent,omitempty" yaml:"idpMetadataContent,omitempty"`
+	SPCert             string `json:"spCert,omitempty" yaml:"spCert,omitempty"`
+	SPKey              string `json:"spKey,omitempty" yaml:"spKey,omitempty"`
+	UIDField           string `json:"uidField,omitempty" yaml:"uidField,omitempty"`
+	UserNameField      string `json:"userNameField,omitempty" yaml:"userNameField,omitempty"`
+}
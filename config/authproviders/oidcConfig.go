@@ -0,0 +1,13 @@
+package authproviders
+
+type OIDCConfig struct {
+	AuthEndpoint     string `json:"authEndpoint,omitempty" yaml:"authEndpoint,omitempty"`
+	ClientID         string `json:"clientID,omitempty" yaml:"clientID,omitempty"`
+	ClientSecret     string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	GroupsClaim      string `json:"groupsClaim,omitempty" yaml:"groupsClaim,omitempty"`
+	Issuer           string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	JWKSURL          string `json:"jwksURL,omitempty" yaml:"jwksURL,omitempty"`
+	Scopes           string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	TokenEndpoint    string `json:"tokenEndpoint,omitempty" yaml:"tokenEndpoint,omitempty"`
+	UserInfoEndpoint string `json:"userInfoEndpoint,omitempty" yaml:"userInfoEndpoint,omitempty"`
+}
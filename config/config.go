@@ -14,6 +14,7 @@ import (
 	"github.com/rancher/tfp-automation/config/authproviders"
 	aws "github.com/rancher/tfp-automation/config/nodeproviders/aws"
 	azure "github.com/rancher/tfp-automation/config/nodeproviders/azure"
+	digitalocean "github.com/rancher/tfp-automation/config/nodeproviders/digitalocean"
 	google "github.com/rancher/tfp-automation/config/nodeproviders/google"
 	harvester "github.com/rancher/tfp-automation/config/nodeproviders/harvester"
 	linode "github.com/rancher/tfp-automation/config/nodeproviders/linode"
@@ -35,6 +36,7 @@ const (
 
 	ClusterOwner Role = "cluster-owner"
 	ProjectOwner Role = "project-owner"
+	CustomRoles  Role = "custom-roles"
 
 	RancherPrivileged PSACT = "rancher-privileged"
 	RancherRestricted PSACT = "rancher-restricted"
@@ -77,16 +79,41 @@ func (c TestClientName) String() string {
 }
 
 type Nodepool struct {
-	Quantity          int64  `json:"quantity,omitempty" yaml:"quantity,omitempty"`
-	Etcd              bool   `json:"etcd,omitempty" yaml:"etcd,omitempty"`
-	Controlplane      bool   `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
-	DiskSize          int64  `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
-	Worker            bool   `json:"worker,omitempty" yaml:"worker,omitempty"`
-	InstanceType      string `json:"instanceType,omitempty" yaml:"instanceType,omitempty"`
-	DesiredSize       int64  `json:"desiredSize,omitempty" yaml:"desiredSize,omitempty"`
-	MaxSize           int64  `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
-	MinSize           int64  `json:"minSize,omitempty" yaml:"minSize,omitempty"`
-	MaxPodsConstraint int64  `json:"maxPodsConstraint,omitempty" yaml:"maxPodsConstraint,omitempty"`
+	Quantity          int64             `json:"quantity,omitempty" yaml:"quantity,omitempty"`
+	Etcd              bool              `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	Controlplane      bool              `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
+	DiskSize          int64             `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
+	Worker            bool              `json:"worker,omitempty" yaml:"worker,omitempty"`
+	InstanceType      string            `json:"instanceType,omitempty" yaml:"instanceType,omitempty"`
+	DesiredSize       int64             `json:"desiredSize,omitempty" yaml:"desiredSize,omitempty"`
+	MaxSize           int64             `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+	MinSize           int64             `json:"minSize,omitempty" yaml:"minSize,omitempty"`
+	MaxPodsConstraint int64             `json:"maxPodsConstraint,omitempty" yaml:"maxPodsConstraint,omitempty"`
+	KubernetesVersion string            `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Spot              bool              `json:"spot,omitempty" yaml:"spot,omitempty"`
+	Taints            []string          `json:"taints,omitempty" yaml:"taints,omitempty"`
+}
+
+type BYOHost struct {
+	Address        string   `json:"address,omitempty" yaml:"address,omitempty"`
+	OS             string   `json:"os,omitempty" yaml:"os,omitempty"`
+	Password       string   `json:"password,omitempty" yaml:"password,omitempty"`
+	PrivateKeyPath string   `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
+	Roles          []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	SSHPort        int64    `json:"sshPort,omitempty" yaml:"sshPort,omitempty"`
+	User           string   `json:"user,omitempty" yaml:"user,omitempty"`
+}
+
+type HostedImport struct {
+	AKSClusterName string `json:"aksClusterName,omitempty" yaml:"aksClusterName,omitempty"`
+	EKSClusterName string `json:"eksClusterName,omitempty" yaml:"eksClusterName,omitempty"`
+	GKEClusterName string `json:"gkeClusterName,omitempty" yaml:"gkeClusterName,omitempty"`
+}
+
+type ImportKubeconfig struct {
+	KubeconfigContent string `json:"kubeconfigContent,omitempty" yaml:"kubeconfigContent,omitempty"`
+	KubeconfigPath    string `json:"kubeconfigPath,omitempty" yaml:"kubeconfigPath,omitempty"`
 }
 
 type Proxy struct {
@@ -108,6 +135,11 @@ type PrivateRegistries struct {
 	Username               string `json:"username,omitempty" yaml:"username,omitempty"`
 }
 
+type S3Credentials struct {
+	AccessKey string `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+}
+
 type Standalone struct {
 	AirgapInternalFQDN             string `json:"airgapInternalFQDN,omitempty" yaml:"airgapInternalFQDN,omitempty"`
 	BootstrapPassword              string `json:"bootstrapPassword,omitempty" yaml:"bootstrapPassword,omitempty"`
@@ -137,6 +169,31 @@ type Standalone struct {
 	UpgradedRancherTagVersion      string `json:"upgradedRancherTagVersion,omitempty" yaml:"upgradedRancherTagVersion,omitempty"`
 }
 
+type StandaloneChartRepo struct {
+	Image    string `json:"image,omitempty" yaml:"image,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	Port     string `json:"port,omitempty" yaml:"port,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+type StandaloneKeycloak struct {
+	AdminPassword string `json:"adminPassword,omitempty" yaml:"adminPassword,omitempty"`
+	AdminUsername string `json:"adminUsername,omitempty" yaml:"adminUsername,omitempty"`
+	Image         string `json:"image,omitempty" yaml:"image,omitempty"`
+	Password      string `json:"password,omitempty" yaml:"password,omitempty"`
+	Port          string `json:"port,omitempty" yaml:"port,omitempty"`
+	Username      string `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+type StandaloneMinIO struct {
+	AccessKey   string `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+	Bucket      string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	ClientImage string `json:"clientImage,omitempty" yaml:"clientImage,omitempty"`
+	Image       string `json:"image,omitempty" yaml:"image,omitempty"`
+	Port        string `json:"port,omitempty" yaml:"port,omitempty"`
+	SecretKey   string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+}
+
 type StandaloneRegistry struct {
 	AssetsPath         string `json:"assetsPath,omitempty" yaml:"assetsPath,omitempty"`
 	Authenticated      bool   `json:"authenticated,omitempty" yaml:"authenticated,omitempty"`
@@ -154,6 +211,8 @@ type TerraformConfig struct {
 	AWSCredentials                      aws.Credentials              `json:"awsCredentials,omitempty" yaml:"awsCredentials,omitempty"`
 	AzureConfig                         azure.Config                 `json:"azureConfig,omitempty" yaml:"azureConfig,omitempty"`
 	AzureCredentials                    azure.Credentials            `json:"azureCredentials,omitempty" yaml:"azureCredentials,omitempty"`
+	DigitalOceanConfig                  digitalocean.Config          `json:"digitalOceanConfig,omitempty" yaml:"digitalOceanConfig,omitempty"`
+	DigitalOceanCredentials             digitalocean.Credentials     `json:"digitalOceanCredentials,omitempty" yaml:"digitalOceanCredentials,omitempty"`
 	GoogleConfig                        google.Config                `json:"googleConfig,omitempty" yaml:"googleConfig,omitempty"`
 	GoogleCredentials                   google.Credentials           `json:"googleCredentials,omitempty" yaml:"googleCredentials,omitempty"`
 	HarvesterConfig                     harvester.Config             `json:"harvesterConfig,omitempty" yaml:"harvesterConfig,omitempty"`
@@ -164,30 +223,102 @@ type TerraformConfig struct {
 	VsphereCredentials                  vsphere.Credentials          `json:"vsphereCredentials,omitempty" yaml:"vsphereCredentials,omitempty"`
 	ADConfig                            authproviders.ADConfig       `json:"adConfig,omitempty" yaml:"adConfig,omitempty"`
 	AzureADConfig                       authproviders.AzureADConfig  `json:"azureADConfig,omitempty" yaml:"azureADConfig,omitempty"`
+	GenericOIDCConfig                   authproviders.OIDCConfig     `json:"genericOIDCConfig,omitempty" yaml:"genericOIDCConfig,omitempty"`
 	GithubConfig                        authproviders.GithubConfig   `json:"githubConfig,omitempty" yaml:"githubConfig,omitempty"`
+	KeycloakConfig                      authproviders.KeycloakConfig `json:"keycloakConfig,omitempty" yaml:"keycloakConfig,omitempty"`
+	KeycloakOIDCConfig                  authproviders.OIDCConfig     `json:"keycloakOIDCConfig,omitempty" yaml:"keycloakOIDCConfig,omitempty"`
 	OktaConfig                          authproviders.OktaConfig     `json:"oktaConfig,omitempty" yaml:"oktaConfig,omitempty"`
 	OpenLDAPConfig                      authproviders.OpenLDAPConfig `json:"openLDAPConfig,omitempty" yaml:"openLDAPConfig,omitempty"`
+	Apps                                []App                        `json:"apps,omitempty" yaml:"apps,omitempty"`
 	AuthProvider                        string                       `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`
+	BYOHosts                            []BYOHost                    `json:"byoHosts,omitempty" yaml:"byoHosts,omitempty"`
 	ResourcePrefix                      string                       `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"`
 	CNI                                 string                       `json:"cni,omitempty" yaml:"cni,omitempty"`
+	CloudProvider                       string                       `json:"cloudProvider,omitempty" yaml:"cloudProvider,omitempty"`
 	ChartValues                         string                       `json:"chartValues,omitempty" yaml:"chartValues,omitempty"`
 	DisableKubeProxy                    string                       `json:"disable-kube-proxy,omitempty" yaml:"disable-kube-proxy,omitempty"`
 	DefaultClusterRoleForProjectMembers string                       `json:"defaultClusterRoleForProjectMembers,omitempty" yaml:"defaultClusterRoleForProjectMembers,omitempty"`
 	EnableNetworkPolicy                 bool                         `json:"enableNetworkPolicy,omitempty" yaml:"enableNetworkPolicy,omitempty"`
+	GlobalRoles                         []GlobalRole                 `json:"globalRoles,omitempty" yaml:"globalRoles,omitempty"`
 	ETCD                                *rkev1.ETCD                  `json:"etcd,omitempty" yaml:"etcd,omitempty"`
 	ETCDRKE1                            *management.ETCDService      `json:"etcdRKE1,omitempty" yaml:"etcdRKE1,omitempty"`
+	ETCDS3Credentials                   *S3Credentials               `json:"etcdS3Credentials,omitempty" yaml:"etcdS3Credentials,omitempty"`
+	Hardened                            bool                         `json:"hardened,omitempty" yaml:"hardened,omitempty"`
+	HostedImport                        *HostedImport                `json:"hostedImport,omitempty" yaml:"hostedImport,omitempty"`
+	ImportKubeconfig                    *ImportKubeconfig            `json:"importKubeconfig,omitempty" yaml:"importKubeconfig,omitempty"`
 	Module                              string                       `json:"module,omitempty" yaml:"module,omitempty"`
 	NetworkPlugin                       string                       `json:"networkPlugin,omitempty" yaml:"networkPlugin,omitempty"`
 	PrivateKeyPath                      string                       `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
+	Projects                            []Project                    `json:"projects,omitempty" yaml:"projects,omitempty"`
 	PrivateRegistries                   *PrivateRegistries           `json:"privateRegistries,omitempty" yaml:"privateRegistries,omitempty"`
 	Proxy                               *Proxy                       `json:"proxy,omitempty" yaml:"proxy,omitempty"`
 	Provider                            string                       `json:"provider,omitempty" yaml:"provider,omitempty"`
+	RoleTemplates                       []RoleTemplate               `json:"roleTemplates,omitempty" yaml:"roleTemplates,omitempty"`
+	SecretsEncryption                   *SecretsEncryption           `json:"secretsEncryption,omitempty" yaml:"secretsEncryption,omitempty"`
 	Standalone                          *Standalone                  `json:"standalone,omitempty" yaml:"standalone,omitempty"`
+	StandaloneChartRepo                 *StandaloneChartRepo         `json:"standaloneChartRepo,omitempty" yaml:"standaloneChartRepo,omitempty"`
+	StandaloneKeycloak                  *StandaloneKeycloak          `json:"standaloneKeycloak,omitempty" yaml:"standaloneKeycloak,omitempty"`
+	StandaloneMinIO                     *StandaloneMinIO             `json:"standaloneMinIO,omitempty" yaml:"standaloneMinIO,omitempty"`
 	StandaloneRegistry                  *StandaloneRegistry          `json:"standaloneRegistry,omitempty" yaml:"standaloneRegistry,omitempty"`
 	TimeSleep                           string                       `json:"timeSleep,omitempty" yaml:"timeSleep,omitempty"`
 	WindowsPrivateKeyPath               string                       `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"`
 }
 
+type App struct {
+	ChartName string `json:"chartName,omitempty" yaml:"chartName,omitempty"`
+	GitBranch string `json:"gitBranch,omitempty" yaml:"gitBranch,omitempty"`
+	GitRepo   string `json:"gitRepo,omitempty" yaml:"gitRepo,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Repo      string `json:"repo,omitempty" yaml:"repo,omitempty"`
+	RepoURL   string `json:"repoURL,omitempty" yaml:"repoURL,omitempty"`
+	Values    string `json:"values,omitempty" yaml:"values,omitempty"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type Project struct {
+	ContainerResourceLimit        *management.ContainerResourceLimit `json:"containerResourceLimit,omitempty" yaml:"containerResourceLimit,omitempty"`
+	Name                          string                             `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespaces                    []Namespace                        `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	NamespaceDefaultResourceQuota *management.ResourceQuotaLimit     `json:"namespaceDefaultResourceQuota,omitempty" yaml:"namespaceDefaultResourceQuota,omitempty"`
+	ResourceQuota                 *management.ResourceQuotaLimit     `json:"resourceQuota,omitempty" yaml:"resourceQuota,omitempty"`
+}
+
+type Namespace struct {
+	ContainerResourceLimit *management.ContainerResourceLimit `json:"containerResourceLimit,omitempty" yaml:"containerResourceLimit,omitempty"`
+	Name                   string                             `json:"name,omitempty" yaml:"name,omitempty"`
+	ResourceQuota          *management.ResourceQuotaLimit     `json:"resourceQuota,omitempty" yaml:"resourceQuota,omitempty"`
+}
+
+type RoleTemplate struct {
+	Context         string                  `json:"context,omitempty" yaml:"context,omitempty"`
+	Name            string                  `json:"name,omitempty" yaml:"name,omitempty"`
+	RoleTemplateIDs []string                `json:"roleTemplateIds,omitempty" yaml:"roleTemplateIds,omitempty"`
+	Rules           []management.PolicyRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+type GlobalRole struct {
+	InheritedClusterRoles []string                `json:"inheritedClusterRoles,omitempty" yaml:"inheritedClusterRoles,omitempty"`
+	Name                  string                  `json:"name,omitempty" yaml:"name,omitempty"`
+	Rules                 []management.PolicyRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+type CertRotation struct {
+	CACertificates bool     `json:"caCertificates,omitempty" yaml:"caCertificates,omitempty"`
+	Generation     int64    `json:"generation,omitempty" yaml:"generation,omitempty"`
+	Services       []string `json:"services,omitempty" yaml:"services,omitempty"`
+}
+
+type Fleet struct {
+	GitServerAddress string `json:"gitServerAddress,omitempty" yaml:"gitServerAddress,omitempty"`
+	GitServerPort    int    `json:"gitServerPort,omitempty" yaml:"gitServerPort,omitempty"`
+}
+
+type SecretsEncryption struct {
+	Enabled                        bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	RotateEncryptionKeysGeneration int64 `json:"rotateEncryptionKeysGeneration,omitempty" yaml:"rotateEncryptionKeysGeneration,omitempty"`
+}
+
 type Snapshots struct {
 	CreateSnapshot  bool   `json:"createSnapshot,omitempty" yaml:"createSnapshot,omitempty"`
 	RestoreSnapshot bool   `json:"restoreSnapshot,omitempty" yaml:"restoreSnapshot,omitempty"`
@@ -196,23 +327,26 @@ type Snapshots struct {
 }
 
 type TerratestConfig struct {
-	AKSKubernetesVersion         string     `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
-	EKSKubernetesVersion         string     `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
-	GKEKubernetesVersion         string     `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
-	KubernetesVersion            string     `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
-	LocalQaseReporting           bool       `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
-	NodeCount                    int64      `json:"nodeCount,omitempty" yaml:"nodeCount,omitempty"`
-	Nodepools                    []Nodepool `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
-	PathToRepo                   string     `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"`
-	PSACT                        string     `json:"psact,omitempty" yaml:"psact,omitempty"`
-	SnapshotInput                Snapshots  `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
-	StandaloneLogging            bool       `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
-	TFLogging                    bool       `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
-	UpgradedAKSKubernetesVersion string     `json:"upgradedAKSKubernetesVersion,omitempty" yaml:"upgradedAKSKubernetesVersion,omitempty"`
-	UpgradedEKSKubernetesVersion string     `json:"upgradedEKSKubernetesVersion,omitempty" yaml:"upgradedEKSKubernetesVersion,omitempty"`
-	UpgradedGKEKubernetesVersion string     `json:"upgradedGKEKubernetesVersion,omitempty" yaml:"upgradedGKEKubernetesVersion,omitempty"`
-	UpgradedKubernetesVersion    string     `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
-	WindowsNodeCount             int64      `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
+	AKSKubernetesVersion         string        `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
+	CertRotationInput            *CertRotation `json:"certRotationInput,omitempty" yaml:"certRotationInput,omitempty"`
+	EKSKubernetesVersion         string        `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
+	FleetInput                   *Fleet        `json:"fleetInput,omitempty" yaml:"fleetInput,omitempty"`
+	GKEKubernetesVersion         string        `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
+	KubernetesVersion            string        `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	LocalQaseReporting           bool          `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
+	NodeCount                    int64         `json:"nodeCount,omitempty" yaml:"nodeCount,omitempty"`
+	Nodepools                    []Nodepool    `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
+	PathToRepo                   string        `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"`
+	PSACT                        string        `json:"psact,omitempty" yaml:"psact,omitempty"`
+	SnapshotInput                Snapshots     `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
+	StandaloneLogging            bool          `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
+	TFLogging                    bool          `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
+	UpgradedAKSKubernetesVersion string        `json:"upgradedAKSKubernetesVersion,omitempty" yaml:"upgradedAKSKubernetesVersion,omitempty"`
+	UpgradedEKSKubernetesVersion string        `json:"upgradedEKSKubernetesVersion,omitempty" yaml:"upgradedEKSKubernetesVersion,omitempty"`
+	UpgradedGKEKubernetesVersion string        `json:"upgradedGKEKubernetesVersion,omitempty" yaml:"upgradedGKEKubernetesVersion,omitempty"`
+	UpgradedKubernetesVersion    string        `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
+	WindowsNodeCount             int64         `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
+	WindowsOSVersion             string        `json:"windowsOSVersion,omitempty" yaml:"windowsOSVersion,omitempty"`
 }
 
 // LoadTFPConfigs loads the TFP configurations from the provided map
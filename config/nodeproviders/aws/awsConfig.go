@@ -3,6 +3,7 @@ package aws
 type Config struct {
 	AMI                   string   `json:"ami,omitempty" yaml:"ami,omitempty"`
 	AWSInstanceType       string   `json:"awsInstanceType,omitempty" yaml:"awsInstanceType,omitempty"`
+	AWSIAMInstanceProfile string   `json:"awsIAMInstanceProfile,omitempty" yaml:"awsIAMInstanceProfile,omitempty"`
 	AWSKeyName            string   `json:"awsKeyName,omitempty" yaml:"awsKeyName,omitempty"`
 	AWSVolumeType         string   `json:"awsVolumeType,omitempty" yaml:"awsVolumeType,omitempty"`
 	AWSRootSize           int64    `json:"awsRootSize,omitempty" yaml:"awsRootSize,omitempty"`
@@ -28,9 +29,11 @@ type Config struct {
 	Timeout               string   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Windows2019AMI        string   `json:"windows2019AMI,omitempty" yaml:"windows2019AMI,omitempty"`
 	Windows2022AMI        string   `json:"windows2022AMI,omitempty" yaml:"windows2022AMI,omitempty"`
+	Windows2025AMI        string   `json:"windows2025AMI,omitempty" yaml:"windows2025AMI,omitempty"`
 	WindowsAWSUser        string   `json:"windowsAWSUser,omitempty" yaml:"windowsAWSUser,omitempty"`
 	Windows2019Password   string   `json:"windows2019Password,omitempty" yaml:"windows2019Password,omitempty"`
 	Windows2022Password   string   `json:"windows2022Password,omitempty" yaml:"windows2022Password,omitempty"`
+	Windows2025Password   string   `json:"windows2025Password,omitempty" yaml:"windows2025Password,omitempty"`
 	WindowsInstanceType   string   `json:"windowsInstanceType,omitempty" yaml:"windowsInstanceType,omitempty"`
 	WindowsKeyName        string   `json:"windowsKeyName,omitempty" yaml:"windowsKeyName,omitempty"`
 	WindowsVolumeType     string   `json:"windowsVolumeType,omitempty" yaml:"windowsVolumeType,omitempty"`
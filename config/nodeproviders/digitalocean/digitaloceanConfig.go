@@ -0,0 +1,15 @@
+package digitalocean
+
+type Config struct {
+	Backups           bool   `json:"backups,omitempty" yaml:"backups,omitempty"`
+	Image             string `json:"image,omitempty" yaml:"image,omitempty"`
+	IPV6              bool   `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+	Monitoring        bool   `json:"monitoring,omitempty" yaml:"monitoring,omitempty"`
+	PrivateNetworking bool   `json:"privateNetworking,omitempty" yaml:"privateNetworking,omitempty"`
+	Region            string `json:"region,omitempty" yaml:"region,omitempty"`
+	Size              string `json:"size,omitempty" yaml:"size,omitempty"`
+	SSHPort           string `json:"sshPort,omitempty" yaml:"sshPort,omitempty"`
+	SSHUser           string `json:"sshUser,omitempty" yaml:"sshUser,omitempty"`
+	Tags              string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Userdata          string `json:"userdata,omitempty" yaml:"userdata,omitempty"`
+}
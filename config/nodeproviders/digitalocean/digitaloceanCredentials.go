@@ -0,0 +1,5 @@
+package digitalocean
+
+type Credentials struct {
+	AccessToken string `json:"accessToken,omitempty" yaml:"accessToken,omitempty"`
+}
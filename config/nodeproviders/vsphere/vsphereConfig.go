@@ -13,6 +13,7 @@ type Config struct {
 	DataCenter             string   `json:"dataCenter,omitempty" yaml:"dataCenter,omitempty"`
 	DataStore              string   `json:"dataStore,omitempty" yaml:"dataStore,omitempty"`
 	DatastoreCluster       string   `json:"datastoreCluster,omitempty" yaml:"datastoreCluster,omitempty"`
+	DatastoreURL           string   `json:"datastoreURL,omitempty" yaml:"datastoreURL,omitempty"`
 	DiskSize               string   `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
 	Folder                 string   `json:"folder,omitempty" yaml:"folder,omitempty"`
 	GuestID                string   `json:"guestID,omitempty" yaml:"guestID,omitempty"`
@@ -32,4 +33,9 @@ type Config struct {
 	VappProperty           []string `json:"vappProperty,omitempty" yaml:"vappProperty,omitempty"`
 	VappTransport          string   `json:"vappTransport,omitempty" yaml:"vappTransport,omitempty"`
 	VsphereUser            string   `json:"vsphereUser,omitempty" yaml:"vsphereUser,omitempty"`
+	Windows2019Template    string   `json:"windows2019Template,omitempty" yaml:"windows2019Template,omitempty"`
+	Windows2022Template    string   `json:"windows2022Template,omitempty" yaml:"windows2022Template,omitempty"`
+	Windows2025Template    string   `json:"windows2025Template,omitempty" yaml:"windows2025Template,omitempty"`
+	WindowsPassword        string   `json:"windowsPassword,omitempty" yaml:"windowsPassword,omitempty"`
+	WindowsUser            string   `json:"windowsUser,omitempty" yaml:"windowsUser,omitempty"`
 }
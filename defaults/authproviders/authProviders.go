@@ -1,9 +1,12 @@
 package authproviders
 
 const (
-	AD       = "ad"
-	AzureAD  = "azureAD"
-	GitHub   = "github"
-	OpenLDAP = "openldap"
-	Okta     = "okta"
+	AD           = "ad"
+	AzureAD      = "azureAD"
+	GenericOIDC  = "genericoidc"
+	GitHub       = "github"
+	Keycloak     = "keycloak"
+	KeycloakOIDC = "keycloakoidc"
+	OpenLDAP     = "openldap"
+	Okta         = "okta"
 )
@@ -0,0 +1,9 @@
+package cloudproviders
+
+const (
+	AWS            = "aws"
+	ExternalAWS    = "external-aws"
+	Harvester      = "harvester"
+	RancherVsphere = "rancher-vsphere"
+	Vsphere        = "vsphere"
+)
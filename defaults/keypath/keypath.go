@@ -3,11 +3,14 @@ package keypath
 const (
 	AirgapKeyPath           = "/modules/airgap"
 	AirgapRKE2KeyPath       = "/modules/airgapRKE2"
+	ChartRepoKeyPath        = "/modules/chartrepo"
+	KeycloakKeyPath         = "/modules/keycloak"
 	DualStackKeyPath        = "/modules/dualstack"
 	DualStackRKE2K3SKeyPath = "/modules/dualstackRKE2K3S"
 	IPv6KeyPath             = "/modules/ipv6"
 	IPv6RKE2K3SKeyPath      = "/modules/ipv6RKE2K3S"
 	K3sKeyPath              = "/modules/k3s"
+	MinIOKeyPath            = "/modules/minio"
 	ProxyKeyPath            = "/modules/proxy"
 	RegistryKeyPath         = "/modules/registries"
 	RKEKeyPath              = "/modules/rke"
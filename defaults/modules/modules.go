@@ -5,25 +5,52 @@ const (
 	EKS = "eks"
 	GKE = "gke"
 
+	ImportAKS = "aks_import"
+	ImportEKS = "eks_import"
+	ImportGKE = "gke_import"
+
+	Azure     = "azure"
 	AzureRKE1 = "azure_rke1"
 	AzureRKE2 = "azure_rke2"
 	AzureK3s  = "azure_k3s"
 
+	CustomBYORKE2        = "byo_rke2_custom"
+	CustomBYORKE2Windows = "byo_rke2_windows_custom"
+	CustomBYOK3s         = "byo_k3s_custom"
+
 	CustomEC2RKE1            = "ec2_rke1_custom"
 	CustomEC2RKE2            = "ec2_rke2_custom"
 	CustomEC2RKE2Windows2019 = "ec2_rke2_windows_2019_custom"
 	CustomEC2RKE2Windows2022 = "ec2_rke2_windows_2022_custom"
+	CustomEC2RKE2Windows2025 = "ec2_rke2_windows_2025_custom"
 	CustomEC2K3s             = "ec2_k3s_custom"
 
-	CustomVsphereRKE1 = "vsphere_rke1_custom"
-	CustomVsphereRKE2 = "vsphere_rke2_custom"
-	CustomVsphereK3s  = "vsphere_k3s_custom"
+	CustomHarvesterRKE1 = "harvester_rke1_custom"
+	CustomHarvesterRKE2 = "harvester_rke2_custom"
+	CustomHarvesterK3s  = "harvester_k3s_custom"
+
+	CustomLinodeRKE1 = "linode_rke1_custom"
+	CustomLinodeRKE2 = "linode_rke2_custom"
+	CustomLinodeK3s  = "linode_k3s_custom"
+
+	CustomVsphereRKE1            = "vsphere_rke1_custom"
+	CustomVsphereRKE2            = "vsphere_rke2_custom"
+	CustomVsphereRKE2Windows2019 = "vsphere_rke2_windows_2019_custom"
+	CustomVsphereRKE2Windows2022 = "vsphere_rke2_windows_2022_custom"
+	CustomVsphereRKE2Windows2025 = "vsphere_rke2_windows_2025_custom"
+	CustomVsphereK3s             = "vsphere_k3s_custom"
+
+	DO     = "do_"
+	DORKE1 = "do_rke1"
+	DORKE2 = "do_rke2"
+	DOK3s  = "do_k3s"
 
 	EC2     = "ec2"
 	EC2RKE1 = "ec2_rke1"
 	EC2RKE2 = "ec2_rke2"
 	EC2K3s  = "ec2_k3s"
 
+	Harvester     = "harvester"
 	HarvesterRKE1 = "harvester_rke1"
 	HarvesterRKE2 = "harvester_rke2"
 	HarvesterK3s  = "harvester_k3s"
@@ -34,6 +61,8 @@ const (
 	ImportEC2RKE2Windows2022 = "ec2_rke2_windows_2022_import"
 	ImportEC2K3s             = "ec2_k3s_import"
 
+	ImportKubeconfig = "import_kubeconfig"
+
 	ImportVsphereRKE1 = "vsphere_rke1_import"
 	ImportVsphereRKE2 = "vsphere_rke2_import"
 	ImportVsphereK3s  = "vsphere_k3s_import"
@@ -42,6 +71,7 @@ const (
 	LinodeRKE2 = "linode_rke2"
 	LinodeK3s  = "linode_k3s"
 
+	Vsphere     = "vsphere"
 	VsphereRKE1 = "vsphere_rke1"
 	VsphereRKE2 = "vsphere_rke2"
 	VsphereK3s  = "vsphere_k3s"
@@ -50,5 +80,6 @@ const (
 	AirgapRKE2            = "airgap_rke2"
 	AirgapRKE2Windows2019 = "airgap_rke2_windows_2019"
 	AirgapRKE2Windows2022 = "airgap_rke2_windows_2022"
+	AirgapRKE2Windows2025 = "airgap_rke2_windows_2025"
 	AirgapK3S             = "airgap_k3s"
 )
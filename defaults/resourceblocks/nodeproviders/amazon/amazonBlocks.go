@@ -13,11 +13,14 @@ const (
 	PublicAccess   = "public_access"
 
 	AMI           = "ami"
+	IAMProfile    = "iam_instance_profile"
 	SecurityGroup = "security_group"
 	SubnetID      = "subnet_id"
 	VPCID         = "vpc_id"
 	Zone          = "zone"
 	RootSize      = "root_size"
+	Tags          = "tags"
+	Userdata      = "userdata"
 
 	NodeGroups   = "node_groups"
 	DiskSize     = "disk_size"
@@ -27,4 +30,10 @@ const (
 	DesiredSize  = "desired_size"
 	MaxSize      = "max_size"
 	MinSize      = "min_size"
+
+	Labels               = "labels"
+	RequestSpotInstances = "request_spot_instances"
+	SpotInstanceTypes    = "spot_instance_types"
+	UserData             = "user_data"
+	Version              = "version"
 )
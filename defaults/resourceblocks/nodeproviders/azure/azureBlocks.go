@@ -45,6 +45,13 @@ const (
 	NodePoolMode        = "mode"
 	OrchestratorVersion = "orchestrator_version"
 	OSDiskSizeGB        = "os_disk_size_gb"
+	ScaleSetEviction    = "scale_set_eviction_policy"
+	ScaleSetPriority    = "scale_set_priority"
 	Taints              = "taints"
 	VMSize              = "vm_size"
+
+	EnableAutoScaling = "enable_auto_scaling"
+	Labels            = "labels"
+	MaxCount          = "max_count"
+	MinCount          = "min_count"
 )
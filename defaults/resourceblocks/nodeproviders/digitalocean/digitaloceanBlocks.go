@@ -0,0 +1,18 @@
+package digitalocean
+
+const (
+	AccessToken                  = "access_token"
+	Backups                      = "backups"
+	DigitalOceanConfig           = "digitalocean_config"
+	DigitalOceanCredentialConfig = "digitalocean_credential_config"
+	Image                        = "image"
+	IPV6                         = "ipv6"
+	Monitoring                   = "monitoring"
+	PrivateNetworking            = "private_networking"
+	Region                       = "region"
+	Size                         = "size"
+	SSHPort                      = "ssh_port"
+	SSHUser                      = "ssh_user"
+	Tags                         = "tags"
+	Userdata                     = "userdata"
+)
@@ -14,4 +14,18 @@ const (
 	InitialNodeCount  = "initial_node_count"
 	MaxPodsConstraint = "max_pods_constraint"
 	Version           = "version"
+
+	Autoscaling  = "autoscaling"
+	Enabled      = "enabled"
+	MaxNodeCount = "max_node_count"
+	MinNodeCount = "min_node_count"
+
+	NodeConfig  = "config"
+	Labels      = "labels"
+	MachineType = "machine_type"
+	Preemptible = "preemptible"
+	Taints      = "taints"
+	TaintEffect = "effect"
+	TaintKey    = "key"
+	TaintValue  = "value"
 )
@@ -1,9 +1,20 @@
 package stevetypes
 
 const (
-	Deployment   = "apps.deployment"
-	Ingress      = "networking.k8s.io.ingress"
-	Machine      = "cluster.x-k8s.io.machine"
-	Provisioning = "provisioning.cattle.io.cluster"
-	Service      = "service"
+	DaemonSet             = "apps.daemonset"
+	ConfigMap             = "configmap"
+	Deployment            = "apps.deployment"
+	Ingress               = "networking.k8s.io.ingress"
+	Job                   = "batch.job"
+	Machine               = "cluster.x-k8s.io.machine"
+	MachineSet            = "cluster.x-k8s.io.machineset"
+	Namespace             = "namespace"
+	Node                  = "node"
+	PersistentVolumeClaim = "persistentvolumeclaim"
+	Pod                   = "pod"
+	Provisioning          = "provisioning.cattle.io.cluster"
+	ResourceQuota         = "resourcequota"
+	RKEControlPlane       = "rke.cattle.io.rkecontrolplane"
+	Secret                = "secret"
+	Service               = "service"
 )
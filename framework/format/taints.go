@@ -0,0 +1,26 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ParseTaint is a function that will parse a taint in the key=value:Effect (or key:Effect) format into a Kubernetes taint.
+func ParseTaint(taint string) (corev1.Taint, error) {
+	keyValue, effect, found := strings.Cut(taint, ":")
+	if !found || keyValue == "" {
+		return corev1.Taint{}, fmt.Errorf("Invalid taint %v. Taints must be in the key=value:Effect format", taint)
+	}
+
+	key, value, _ := strings.Cut(keyValue, "=")
+
+	switch corev1.TaintEffect(effect) {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return corev1.Taint{}, fmt.Errorf("Invalid effect %v for taint %v", effect, taint)
+	}
+
+	return corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)}, nil
+}
@@ -0,0 +1,38 @@
+package format
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+)
+
+// WindowsVersion is a function that will return the Windows Server version of the Windows nodes. The version is taken from
+// the module name, e.g. ec2_rke2_windows_2022_custom, or from the windowsOSVersion set in the terratest config when the module
+// name has none. A windowsOSVersion that conflicts with the version in the module name is rejected.
+func WindowsVersion(module, windowsOSVersion string) (string, error) {
+	versions := []string{defaults.Windows2019, defaults.Windows2022, defaults.Windows2025}
+
+	if windowsOSVersion != "" && !slices.Contains(versions, windowsOSVersion) {
+		return "", fmt.Errorf("Unsupported Windows OS version: %v", windowsOSVersion)
+	}
+
+	for _, version := range versions {
+		if !strings.Contains(module, version) {
+			continue
+		}
+
+		if windowsOSVersion != "" && windowsOSVersion != version {
+			return "", fmt.Errorf("windowsOSVersion %v conflicts with the Windows version %v of module: %v", windowsOSVersion, version, module)
+		}
+
+		return version, nil
+	}
+
+	if windowsOSVersion != "" {
+		return windowsOSVersion, nil
+	}
+
+	return "", fmt.Errorf("windowsOSVersion must be set for module: %v", module)
+}
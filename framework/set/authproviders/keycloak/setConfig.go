@@ -0,0 +1,55 @@
+package keycloak
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	keycloakConfig = "rancher2_auth_config_keycloak"
+
+	resource           = "resource"
+	displayNameField   = "display_name_field"
+	entityID           = "entity_id"
+	groupsField        = "groups_field"
+	idpMetadataContent = "idp_metadata_content"
+	rancherAPIHost     = "rancher_api_host"
+	spCert             = "sp_cert"
+	spKey              = "sp_key"
+	uidField           = "uid_field"
+	userNameField      = "user_name_field"
+)
+
+// SetKeycloak is a function that will set the Keycloak SAML configurations in the main.tf file.
+func SetKeycloak(rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File) error {
+	keycloakBlock := rootBody.AppendNewBlock(resource, []string{keycloakConfig, keycloakConfig})
+	keycloakBlockBody := keycloakBlock.Body()
+
+	keycloakBlockBody.SetAttributeValue(displayNameField, cty.StringVal(terraformConfig.KeycloakConfig.DisplayNameField))
+
+	if terraformConfig.KeycloakConfig.EntityID != "" {
+		keycloakBlockBody.SetAttributeValue(entityID, cty.StringVal(terraformConfig.KeycloakConfig.EntityID))
+	}
+
+	keycloakBlockBody.SetAttributeValue(groupsField, cty.StringVal(terraformConfig.KeycloakConfig.GroupsField))
+	keycloakBlockBody.SetAttributeValue(idpMetadataContent, cty.StringVal(terraformConfig.KeycloakConfig.IdpMetadataContent))
+	keycloakBlockBody.SetAttributeValue(rancherAPIHost, cty.StringVal("https://"+rancherConfig.Host))
+	keycloakBlockBody.SetAttributeValue(spCert, cty.StringVal(terraformConfig.KeycloakConfig.SPCert))
+	keycloakBlockBody.SetAttributeValue(spKey, cty.StringVal(terraformConfig.KeycloakConfig.SPKey))
+	keycloakBlockBody.SetAttributeValue(uidField, cty.StringVal(terraformConfig.KeycloakConfig.UIDField))
+	keycloakBlockBody.SetAttributeValue(userNameField, cty.StringVal(terraformConfig.KeycloakConfig.UserNameField))
+
+	_, err := file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write Keycloak configurations to main.tf file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config/authproviders"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	genericOIDCConfig  = "rancher2_auth_config_generic_oidc"
+	keycloakOIDCConfig = "rancher2_auth_config_keycloak_oidc"
+
+	resource         = "resource"
+	authEndpoint     = "auth_endpoint"
+	clientID         = "client_id"
+	clientSecret     = "client_secret"
+	groupsClaim      = "groups_claim"
+	issuer           = "issuer"
+	jwksURL          = "jwks_url"
+	rancherURL       = "rancher_url"
+	scopes           = "scopes"
+	tokenEndpoint    = "token_endpoint"
+	userInfoEndpoint = "user_info_endpoint"
+
+	verifyAuthPath = "/verify-auth"
+)
+
+// SetKeycloakOIDC is a function that will set the Keycloak OIDC configurations in the main.tf file.
+func SetKeycloakOIDC(rancherConfig *rancher.Config, oidcConfig authproviders.OIDCConfig, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File) error {
+	setOIDC(rootBody, keycloakOIDCConfig, rancherConfig, oidcConfig)
+
+	_, err := file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write Keycloak OIDC configurations to main.tf file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetGenericOIDC is a function that will set the generic OIDC configurations in the main.tf file.
+func SetGenericOIDC(rancherConfig *rancher.Config, oidcConfig authproviders.OIDCConfig, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File) error {
+	setOIDC(rootBody, genericOIDCConfig, rancherConfig, oidcConfig)
+
+	_, err := file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write generic OIDC configurations to main.tf file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// RancherURL is a function that will return the redirect URL of the Rancher server registered with the OIDC client.
+func RancherURL(rancherConfig *rancher.Config) string {
+	return "https://" + rancherConfig.Host + verifyAuthPath
+}
+
+// setOIDC is a helper function that will set the OIDC auth config block of the given resource type in the main.tf file. The
+// endpoints discovered from the issuer are only set when they are configured.
+func setOIDC(rootBody *hclwrite.Body, resourceType string, rancherConfig *rancher.Config, oidcConfig authproviders.OIDCConfig) {
+	oidcBlock := rootBody.AppendNewBlock(resource, []string{resourceType, resourceType})
+	oidcBlockBody := oidcBlock.Body()
+
+	oidcBlockBody.SetAttributeValue(clientID, cty.StringVal(oidcConfig.ClientID))
+	oidcBlockBody.SetAttributeValue(clientSecret, cty.StringVal(oidcConfig.ClientSecret))
+	oidcBlockBody.SetAttributeValue(issuer, cty.StringVal(oidcConfig.Issuer))
+	oidcBlockBody.SetAttributeValue(authEndpoint, cty.StringVal(oidcConfig.AuthEndpoint))
+	oidcBlockBody.SetAttributeValue(rancherURL, cty.StringVal(RancherURL(rancherConfig)))
+
+	optionalValues := []struct {
+		name  string
+		value string
+	}{
+		{tokenEndpoint, oidcConfig.TokenEndpoint},
+		{userInfoEndpoint, oidcConfig.UserInfoEndpoint},
+		{jwksURL, oidcConfig.JWKSURL},
+		{scopes, oidcConfig.Scopes},
+		{groupsClaim, oidcConfig.GroupsClaim},
+	}
+
+	for _, optionalValue := range optionalValues {
+		if optionalValue.value != "" {
+			oidcBlockBody.SetAttributeValue(optionalValue.name, cty.StringVal(optionalValue.value))
+		}
+	}
+}
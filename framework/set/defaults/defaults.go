@@ -18,6 +18,7 @@ const (
 	Airgap       = "airgap"
 	Custom       = "custom"
 	Import       = "import"
+	Imported     = "imported"
 	Registry     = "registry"
 
 	Rancher2Source      = "rancher/rancher2"
@@ -31,6 +32,9 @@ const (
 	SecretV2                    = "rancher2_secret_v2"
 
 	AgentEnvVars                        = "agent_env_vars"
+	AdditionalManifest                  = "additional_manifest"
+	Annotations                         = "annotations"
+	CloudProvider                       = "cloud_provider"
 	RkeConfig                           = "rke_config"
 	KubernetesVersion                   = "kubernetes_version"
 	Network                             = "network"
@@ -53,6 +57,8 @@ const (
 	MachineConfig         = "machine_config"
 	MachineGlobalConfig   = "machine_global_config"
 	MachineSelectorConfig = "machine_selector_config"
+	MachineLabelSelector  = "machine_label_selector"
+	MatchLabels           = "match_labels"
 	MachinePools          = "machine_pools"
 	NodePool              = "node-pool"
 	Etcd                  = "etcd"
@@ -195,9 +201,15 @@ const (
 	PrivateKey       = "private_key"
 	Provisioner      = "provisioner"
 	RemoteExec       = "remote-exec"
+	LocalExec        = "local-exec"
+	Command          = "command"
+	Environment      = "environment"
 	Ssh              = "ssh"
+	When             = "when"
+	Destroy          = "destroy"
 	WinRM            = "winrm"
 	UseNTLM          = "use_ntlm"
+	HTTPS            = "https"
 	User             = "user"
 	Password         = "password"
 	UserData         = "user_data"
@@ -214,9 +226,14 @@ const (
 	Max              = "max"
 
 	Aws     = "aws"
+	BYO     = "byo"
 	Linode  = "linode"
 	Vsphere = "vsphere"
 
+	Windows2019 = "2019"
+	Windows2022 = "2022"
+	Windows2025 = "2025"
+
 	AwsSource     = "hashicorp/aws"
 	LinodeSource  = "linode/linode"
 	RKESource     = "rancher/rke"
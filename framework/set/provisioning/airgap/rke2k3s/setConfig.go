@@ -8,6 +8,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/airgap"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/airgap/nullresource"
@@ -47,7 +48,12 @@ func SetAirgapRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 	}
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		aws.CreateAirgappedWindowsAWSInstances(rootBody, terraformConfig, airgapWindowsNode+"_"+terraformConfig.ResourcePrefix)
+		windowsVersion, err := format.WindowsVersion(terraformConfig.Module, terratestConfig.WindowsOSVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		aws.CreateAirgappedWindowsAWSInstances(rootBody, terraformConfig, airgapWindowsNode+"_"+terraformConfig.ResourcePrefix, windowsVersion)
 		rootBody.AppendNewline()
 	}
 
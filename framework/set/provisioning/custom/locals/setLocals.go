@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -36,6 +37,10 @@ func SetLocals(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 
 	localsBlockBody.SetAttributeRaw(defaults.ResourcePrefix, resourcePrefixValue)
 
+	if terraformConfig.Provider == defaults.Harvester && strings.Contains(terraformConfig.Module, defaults.Custom) {
+		harvester.SetCloudInitLocal(localsBlockBody, terraformConfig)
+	}
+
 	if !strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
 		setV2ClusterLocalBlock(localsBlockBody, terraformConfig, customClusterNames)
 	}
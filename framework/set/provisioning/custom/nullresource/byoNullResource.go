@@ -0,0 +1,319 @@
+package nullresource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	etcdRole         = "etcd"
+	controlPlaneRole = "controlplane"
+	workerRole       = "worker"
+
+	triggerHost           = "host"
+	triggerPassword       = "password"
+	triggerPort           = "port"
+	triggerPrivateKeyPath = "private_key_path"
+	triggerUser           = "user"
+
+	rke2UninstallScript        = "/usr/local/bin/rke2-uninstall.sh"
+	rke2OptUninstallScript     = "/opt/rke2/bin/rke2-uninstall.sh"
+	k3sUninstallScript         = "/usr/local/bin/k3s-uninstall.sh"
+	k3sAgentUninstallScript    = "/usr/local/bin/k3s-agent-uninstall.sh"
+	systemAgentUninstallScript = "/usr/local/bin/rancher-system-agent-uninstall.sh"
+	rke2WindowsUninstallScript = "C:/usr/local/bin/rke2-uninstall.ps1"
+	winsUninstallScript        = "C:/usr/local/bin/rancher-wins-uninstall.ps1"
+
+	defaultSSHPort   = 22
+	defaultWinRMPort = 5985
+
+	rootUser = "root"
+)
+
+// byoNullResource is a function that will set a null_resource per Linux host in the BYO inventory in the main.tf file,
+// to register the hosts to the cluster and uninstall RKE2/K3s from them on destroy.
+func byoNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	if len(terraformConfig.BYOHosts) == 0 {
+		return fmt.Errorf("No byoHosts specified for module: %v", terraformConfig.Module)
+	}
+
+	for i, host := range terraformConfig.BYOHosts {
+		if host.OS == defaults.Windows {
+			continue
+		}
+
+		roleFlags, err := byoRoleFlags(host.Roles)
+		if err != nil {
+			return fmt.Errorf("Invalid roles for BYO host %v: %v", host.Address, err)
+		}
+
+		nodeName := terraformConfig.ResourcePrefix + "-" + strconv.Itoa(i)
+
+		nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + nodeName})
+		nullResourceBlockBody := nullResourceBlock.Body()
+
+		err = setBYOTriggers(nullResourceBlockBody, terraformConfig, host)
+		if err != nil {
+			return err
+		}
+
+		provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+		provisionerBlockBody := provisionerBlock.Body()
+
+		regCommand := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(`["${` + defaults.Local + `.` + terraformConfig.ResourcePrefix + "_" +
+				defaults.InsecureNodeCommand + `} ` + roleFlags + ` ` + defaults.NodeNameFlag + ` ` + nodeName + `"]`)},
+		}
+
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
+		setBYOConnection(provisionerBlockBody, terraformConfig, host, defaults.Ssh)
+
+		destroyBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+		destroyBlockBody := destroyBlock.Body()
+
+		destroyBlockBody.SetAttributeRaw(defaults.When, hclwrite.TokensForIdentifier(defaults.Destroy))
+		destroyBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal(byoUninstallCommands(terraformConfig, host)))
+		setBYODestroyConnection(destroyBlockBody, defaults.Ssh)
+
+		setBYODependsOn(nullResourceBlockBody, terraformConfig)
+
+		rootBody.AppendNewline()
+	}
+
+	return nil
+}
+
+// byoWindowsNullResource is a function that will set a null_resource per Windows host in the BYO inventory in the main.tf file,
+// to register the hosts to the cluster and uninstall RKE2 from them on destroy.
+func byoWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, clusterName string) error {
+	for i, host := range terraformConfig.BYOHosts {
+		if host.OS != defaults.Windows {
+			continue
+		}
+
+		nodeName := clusterName + "-" + defaults.Windows + "-" + strconv.Itoa(i)
+
+		nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + nodeName})
+		nullResourceBlockBody := nullResourceBlock.Body()
+
+		err := setBYOTriggers(nullResourceBlockBody, terraformConfig, host)
+		if err != nil {
+			return err
+		}
+
+		provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+		provisionerBlockBody := provisionerBlock.Body()
+
+		var regCommand hclwrite.Tokens
+		if terraformConfig.Proxy != nil && terraformConfig.Proxy.ProxyBastion != "" {
+			regCommand = hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(`["powershell.exe ${` + defaults.Local + `.` + clusterName + "_" + defaults.InsecureWindowsProxyNodeCommand + `}"]`)},
+			}
+		} else {
+			regCommand = hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(`["powershell.exe ${` + defaults.Local + `.` + clusterName + "_" + defaults.InsecureWindowsNodeCommand + `}"]`)},
+			}
+		}
+
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
+		setBYOConnection(provisionerBlockBody, terraformConfig, host, defaults.WinRM)
+
+		destroyBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+		destroyBlockBody := destroyBlock.Body()
+
+		destroyBlockBody.SetAttributeRaw(defaults.When, hclwrite.TokensForIdentifier(defaults.Destroy))
+		destroyBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal(byoWindowsUninstallCommands()))
+		setBYODestroyConnection(destroyBlockBody, defaults.WinRM)
+
+		setBYODependsOn(nullResourceBlockBody, terraformConfig)
+
+		rootBody.AppendNewline()
+	}
+
+	return nil
+}
+
+// setBYOTriggers stores the connection details of the host as triggers, as destroy-time provisioners may only reference self.
+// Linux hosts are uninstalled over SSH with their private key, so their password is left out of the Terraform state. Windows hosts
+// are uninstalled over WinRM, which only authenticates with a password, so the password of Windows hosts is stored in the state.
+func setBYOTriggers(nullResourceBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, host config.BYOHost) error {
+	if host.OS == defaults.Windows {
+		if host.Password == "" {
+			return fmt.Errorf("A password is required to uninstall Windows BYO host %v on destroy", host.Address)
+		}
+
+		nullResourceBlockBody.SetAttributeValue(defaults.Triggers, cty.MapVal(map[string]cty.Value{
+			triggerHost:     cty.StringVal(host.Address),
+			triggerPassword: cty.StringVal(host.Password),
+			triggerPort:     cty.StringVal(strconv.Itoa(defaultWinRMPort)),
+			triggerUser:     cty.StringVal(host.User),
+		}))
+
+		return nil
+	}
+
+	privateKeyPath := byoPrivateKeyPath(terraformConfig, host)
+	if privateKeyPath == "" {
+		return fmt.Errorf("A privateKeyPath is required to uninstall BYO host %v on destroy", host.Address)
+	}
+
+	nullResourceBlockBody.SetAttributeValue(defaults.Triggers, cty.MapVal(map[string]cty.Value{
+		triggerHost:           cty.StringVal(host.Address),
+		triggerPort:           cty.StringVal(strconv.FormatInt(byoSSHPort(host), 10)),
+		triggerPrivateKeyPath: cty.StringVal(privateKeyPath),
+		triggerUser:           cty.StringVal(host.User),
+	}))
+
+	return nil
+}
+
+// setBYOConnection sets the connection block of the registration provisioner of the host.
+func setBYOConnection(provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, host config.BYOHost, connectionType string) {
+	connectionBlock := provisionerBlockBody.AppendNewBlock(defaults.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(connectionType))
+	connectionBlockBody.SetAttributeValue(defaults.Host, cty.StringVal(host.Address))
+	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(host.User))
+
+	if host.Password != "" {
+		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(host.Password))
+	}
+
+	if connectionType == defaults.WinRM {
+		connectionBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(defaultWinRMPort))
+		connectionBlockBody.SetAttributeValue(defaults.HTTPS, cty.BoolVal(false))
+		connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
+		connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
+
+		return
+	}
+
+	connectionBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(byoSSHPort(host)))
+
+	keyPathExpression := defaults.File + `("` + byoPrivateKeyPath(terraformConfig, host) + `")`
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, hclwrite.TokensForIdentifier(keyPathExpression))
+}
+
+// setBYODestroyConnection sets the connection block of the uninstall provisioner using the triggers of the enclosing null_resource.
+// Windows hosts are connected to over WinRM with the same settings as their registration.
+func setBYODestroyConnection(provisionerBlockBody *hclwrite.Body, connectionType string) {
+	connectionBlock := provisionerBlockBody.AppendNewBlock(defaults.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(connectionType))
+	connectionBlockBody.SetAttributeRaw(defaults.Host, hclwrite.TokensForIdentifier(selfTrigger(triggerHost)))
+	connectionBlockBody.SetAttributeRaw(defaults.Port, hclwrite.TokensForIdentifier(selfTrigger(triggerPort)))
+	connectionBlockBody.SetAttributeRaw(defaults.User, hclwrite.TokensForIdentifier(selfTrigger(triggerUser)))
+
+	if connectionType == defaults.WinRM {
+		connectionBlockBody.SetAttributeRaw(defaults.Password, hclwrite.TokensForIdentifier(selfTrigger(triggerPassword)))
+		connectionBlockBody.SetAttributeValue(defaults.HTTPS, cty.BoolVal(false))
+		connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
+		connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
+
+		return
+	}
+
+	keyPathExpression := defaults.File + `(` + selfTrigger(triggerPrivateKeyPath) + `)`
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, hclwrite.TokensForIdentifier(keyPathExpression))
+}
+
+// byoSSHPort returns the SSH port of the host, defaulting to 22.
+func byoSSHPort(host config.BYOHost) int64 {
+	if host.SSHPort == 0 {
+		return defaultSSHPort
+	}
+
+	return host.SSHPort
+}
+
+// byoPrivateKeyPath returns the private key of the host, falling back to the privateKeyPath of the Terraform config for Linux hosts.
+func byoPrivateKeyPath(terraformConfig *config.TerraformConfig, host config.BYOHost) string {
+	if host.PrivateKeyPath == "" && host.OS != defaults.Windows {
+		return terraformConfig.PrivateKeyPath
+	}
+
+	return host.PrivateKeyPath
+}
+
+// setBYODependsOn makes the null_resource depend on the cluster so hosts are cleaned up before the cluster is deleted.
+func setBYODependsOn(nullResourceBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	clusterV2Expression := `[` + defaults.ClusterV2 + `.` + terraformConfig.ResourcePrefix + `]`
+	clusterV2 := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(clusterV2Expression)},
+	}
+
+	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, clusterV2)
+}
+
+// byoRoleFlags converts the roles of a BYO host into the flags expected by the node registration command.
+func byoRoleFlags(roles []string) (string, error) {
+	if len(roles) == 0 {
+		return "", fmt.Errorf("at least one role is required")
+	}
+
+	var flags []string
+	for _, role := range roles {
+		switch role {
+		case etcdRole:
+			flags = append(flags, defaults.EtcdRoleFlag)
+		case controlPlaneRole:
+			flags = append(flags, defaults.ControlPlaneRoleFlag)
+		case workerRole:
+			flags = append(flags, defaults.WorkerRoleFlag)
+		default:
+			return "", fmt.Errorf("unsupported role %v", role)
+		}
+	}
+
+	return strings.Join(flags, " "), nil
+}
+
+// byoUninstallCommands returns the commands that remove the distro and the rancher-system-agent from a Linux host. The scripts are
+// run with sudo unless the host is accessed as root, as sudo may not be installed on those hosts.
+func byoUninstallCommands(terraformConfig *config.TerraformConfig, host config.BYOHost) []cty.Value {
+	var scripts []string
+	if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		scripts = []string{k3sUninstallScript, k3sAgentUninstallScript}
+	} else {
+		scripts = []string{rke2UninstallScript, rke2OptUninstallScript}
+	}
+
+	scripts = append(scripts, systemAgentUninstallScript)
+
+	sudo := "sudo "
+	if host.User == rootUser {
+		sudo = ""
+	}
+
+	var commands []cty.Value
+	for _, script := range scripts {
+		commands = append(commands, cty.StringVal(fmt.Sprintf("if [ -x %s ]; then %s%s; fi", script, sudo, script)))
+	}
+
+	return commands
+}
+
+// byoWindowsUninstallCommands returns the commands that remove RKE2 and rancher-wins from a Windows host.
+func byoWindowsUninstallCommands() []cty.Value {
+	var commands []cty.Value
+	for _, script := range []string{rke2WindowsUninstallScript, winsUninstallScript} {
+		commands = append(commands, cty.StringVal(fmt.Sprintf(`powershell.exe -Command "if (Test-Path %s) { & %s }"`, script, script)))
+	}
+
+	return commands
+}
+
+// selfTrigger returns the expression referencing the given trigger of the enclosing null_resource.
+func selfTrigger(name string) string {
+	return defaults.Self + `.` + defaults.Triggers + `.` + name
+}
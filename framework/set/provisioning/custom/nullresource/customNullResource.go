@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -15,14 +16,24 @@ import (
 // CustomNullResource is a function that will set the null_resource configurations in the main.tf file,
 // to register the nodes to the cluster
 func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) error {
+	if terraformConfig.Provider == defaults.BYO {
+		return byoNullResource(rootBody, terraformConfig)
+	}
+
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + terraformConfig.ResourcePrefix})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
 	var countExpression string
 	if strings.Contains(terraformConfig.Provider, defaults.Aws) {
 		countExpression = defaults.Length + `(` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `)`
+	} else if strings.Contains(terraformConfig.Provider, defaults.Harvester) {
+		countExpression = defaults.Length + `(` + defaults.HarvesterVirtualMachine + `.` + terraformConfig.ResourcePrefix + `)`
+	} else if strings.Contains(terraformConfig.Provider, defaults.Linode) {
+		countExpression = defaults.Length + `(` + defaults.LinodeInstance + `.` + terraformConfig.ResourcePrefix + `)`
 	} else if strings.Contains(terraformConfig.Provider, defaults.Vsphere) {
 		countExpression = defaults.Length + `(` + defaults.VsphereVirtualMachine + `.` + terraformConfig.ResourcePrefix + `)`
+	} else {
+		return fmt.Errorf("Unsupported provider for custom clusters: %v", terraformConfig.Provider)
 	}
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.Count, hclwrite.TokensForIdentifier(countExpression))
@@ -63,6 +74,13 @@ func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	case defaults.Aws:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.AwsInstance, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.PublicIp)
+	case defaults.Harvester:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.HarvesterConfig.SSHUser))
+		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s[0].%s}"`, defaults.HarvesterVirtualMachine, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.NetworkInterface, defaults.IPAddress)
+	case defaults.Linode:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(linode.RootUser))
+		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.LinodeInstance, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.IPAddress)
 	case defaults.Vsphere:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereConfig.VsphereUser))
 		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.VsphereVirtualMachine, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.DefaultIPAddress)
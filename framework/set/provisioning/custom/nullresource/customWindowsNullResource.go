@@ -1,23 +1,51 @@
 package nullresource
 
 import (
-	"strings"
+	"fmt"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CustomWindowsNullResource is a function that will set the Windows null_resource configurations in the main.tf file,
 // to register the nodes to the cluster
-func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, clusterName string) error {
+func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	clusterName string) error {
+	if terraformConfig.Provider == defaults.BYO {
+		return byoWindowsNullResource(rootBody, terraformConfig, clusterName)
+	}
+
+	windowsVersion, err := format.WindowsVersion(terraformConfig.Module, terratestConfig.WindowsOSVersion)
+	if err != nil {
+		return err
+	}
+
+	var instance, user, password, ipAddress string
+
+	switch terraformConfig.Provider {
+	case defaults.Aws:
+		instance = defaults.AwsInstance
+		user = terraformConfig.AWSConfig.WindowsAWSUser
+		password = aws.WindowsPassword(terraformConfig, windowsVersion)
+		ipAddress = defaults.PublicIp
+	case defaults.Vsphere:
+		instance = defaults.VsphereVirtualMachine
+		user = terraformConfig.VsphereConfig.WindowsUser
+		password = terraformConfig.VsphereConfig.WindowsPassword
+		ipAddress = defaults.DefaultIPAddress
+	default:
+		return fmt.Errorf("Unsupported provider for Windows custom clusters: %v", terraformConfig.Provider)
+	}
+
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + clusterName + "-windows"})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
-	countExpression := defaults.Length + `(` + defaults.AwsInstance + `.` + clusterName + `-windows)`
+	countExpression := defaults.Length + `(` + instance + `.` + clusterName + `-windows)`
 	nullResourceBlockBody.SetAttributeRaw(defaults.Count, hclwrite.TokensForIdentifier(countExpression))
 
 	provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
@@ -27,18 +55,13 @@ func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlockBody := connectionBlock.Body()
 
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.WinRM))
-	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
-
-	if strings.Contains(terraformConfig.Module, modules.CustomEC2RKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, modules.CustomEC2RKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(user))
+	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(password))
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
 
-	hostExpression := defaults.AwsInstance + `.` + clusterName + `-windows[` + defaults.Count + `.` + defaults.Index + `].` + defaults.PublicIp
+	hostExpression := instance + `.` + clusterName + `-windows[` + defaults.Count + `.` + defaults.Index + `].` + ipAddress
 	host := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
 	}
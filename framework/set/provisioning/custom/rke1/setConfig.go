@@ -12,6 +12,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
@@ -20,6 +22,15 @@ func SetCustomRKE1(terraformConfig *config.TerraformConfig, terratestConfig *con
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
 	if strings.Contains(terraformConfig.Module, modules.CustomEC2RKE1) {
 		aws.CreateAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	} else if strings.Contains(terraformConfig.Module, modules.CustomHarvesterRKE1) {
+		err := harvester.CreateKubeconfigFile(file, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		harvester.CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	} else if strings.Contains(terraformConfig.Module, modules.CustomLinodeRKE1) {
+		linode.CreateLinodeInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 	} else if strings.Contains(terraformConfig.Module, modules.CustomVsphereRKE1) {
 		dataCenterExpression := fmt.Sprintf(defaults.Data + `.` + defaults.VsphereDatacenter + `.` + defaults.VsphereDatacenter + `.id`)
 		dataCenterValue := hclwrite.Tokens{
@@ -46,7 +57,10 @@ func SetCustomRKE1(terraformConfig *config.TerraformConfig, terratestConfig *con
 
 	SetRancher2Cluster(rootBody, terraformConfig, terratestConfig)
 
-	nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
+	err := nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return newFile, file, nil
 }
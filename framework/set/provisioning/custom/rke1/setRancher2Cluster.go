@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke1"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -24,5 +25,26 @@ func SetRancher2Cluster(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 
 	networkBlockBody.SetAttributeValue(defaults.Plugin, cty.StringVal(terraformConfig.CNI))
 
+	if terraformConfig.ETCDRKE1 != nil {
+		err := rke1.SetEtcdConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if terraformConfig.SecretsEncryption != nil {
+		err := rke1.SetSecretsEncryptionConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if terratestConfig.CertRotationInput != nil {
+		err := rke1.SetRotateCertificates(rkeConfigBlockBody, terratestConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -9,9 +9,12 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
@@ -21,6 +24,15 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 	switch terraformConfig.Provider {
 	case defaults.Aws:
 		aws.CreateAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	case defaults.Harvester:
+		err := harvester.CreateKubeconfigFile(file, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		harvester.CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	case defaults.Linode:
+		linode.CreateLinodeInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 	case defaults.Vsphere:
 		dataCenterExpression := fmt.Sprintf(defaults.Data + `.` + defaults.VsphereDatacenter + `.` + defaults.VsphereDatacenter + `.id`)
 		dataCenterValue := hclwrite.Tokens{
@@ -45,9 +57,11 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 		vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 	}
 
-	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		rootBody.AppendNewline()
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && terraformConfig.Provider != defaults.BYO {
+		err := setWindowsInstances(terraformConfig, terratestConfig, rootBody)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	rootBody.AppendNewline()
@@ -55,8 +69,38 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 	SetRancher2ClusterV2(rootBody, terraformConfig, terratestConfig)
 	rootBody.AppendNewline()
 
-	nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
+	err := nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	return newFile, file, nil
 }
+
+// setWindowsInstances is a function that will set the Windows instances of the Windows OS version in the main.tf file.
+func setWindowsInstances(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, rootBody *hclwrite.Body) error {
+	windowsVersion, err := format.WindowsVersion(terraformConfig.Module, terratestConfig.WindowsOSVersion)
+	if err != nil {
+		return err
+	}
+
+	rootBody.AppendNewline()
+
+	switch terraformConfig.Provider {
+	case defaults.Aws:
+		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix, windowsVersion)
+	case defaults.Vsphere:
+		dataCenterExpression := defaults.Data + `.` + defaults.VsphereDatacenter + `.` + defaults.VsphereDatacenter + `.id`
+
+		vsphere.CreateVsphereWindowsVirtualMachineTemplate(rootBody, terraformConfig, hclwrite.TokensForIdentifier(dataCenterExpression), windowsVersion)
+		rootBody.AppendNewline()
+
+		vsphere.CreateVsphereWindowsVirtualMachines(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	default:
+		return fmt.Errorf("Unsupported provider for Windows custom clusters: %v", terraformConfig.Provider)
+	}
+
+	return nil
+}
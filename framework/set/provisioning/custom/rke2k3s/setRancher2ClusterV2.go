@@ -33,6 +33,12 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 			hcl.TraverseRoot{Name: "<<EOF\ncni: " + terraformConfig.CNI + "\nEOF"},
 		})
 
+		rkeConfigBlockBody.SetAttributeRaw(defaults.MachineGlobalConfig, machineGlobalConfigValue)
+	} else if secretsEncryptionConfig := v2.SecretsEncryptionConfig(terraformConfig); secretsEncryptionConfig != "" {
+		machineGlobalConfigValue := hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "<<EOF\n" + secretsEncryptionConfig + "\nEOF"},
+		})
+
 		rkeConfigBlockBody.SetAttributeRaw(defaults.MachineGlobalConfig, machineGlobalConfigValue)
 	}
 
@@ -46,8 +52,34 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 		v2.SetPrivateRegistryConfig(rkeConfigBlockBody, terraformConfig)
 	}
 
+	if terraformConfig.ETCD != nil {
+		err := v2.SetEtcdConfig(rootBody, rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if terraformConfig.SecretsEncryption != nil && terraformConfig.SecretsEncryption.RotateEncryptionKeysGeneration > 0 {
+		err := v2.SetRotateEncryptionKeys(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if terratestConfig.CertRotationInput != nil {
+		err := v2.SetRotateCertificates(rkeConfigBlockBody, terratestConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	if strings.Contains(terraformConfig.Module, clustertypes.CUSTOM) && strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		dependsOnBlock := `[` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows]`
+		windowsInstance := defaults.AwsInstance
+		if terraformConfig.Provider == defaults.Vsphere {
+			windowsInstance = defaults.VsphereVirtualMachine
+		}
+
+		dependsOnBlock := `[` + windowsInstance + `.` + terraformConfig.ResourcePrefix + `-windows]`
 
 		server := hclwrite.Tokens{
 			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnBlock)},
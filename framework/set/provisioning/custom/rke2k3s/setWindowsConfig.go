@@ -11,7 +11,11 @@ import (
 // SetCustomRKE2Windows is a function that will set the custom RKE2 cluster configurations in the main.tf file.
 func SetCustomRKE2Windows(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, configMap []map[string]any,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
-	nullresource.CustomWindowsNullResource(rootBody, terraformConfig, terraformConfig.ResourcePrefix)
+	err := nullresource.CustomWindowsNullResource(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	return newFile, file, nil
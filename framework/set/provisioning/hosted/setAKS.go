@@ -17,20 +17,7 @@ import (
 // SetAKS is a function that will set the AKS configurations in the main.tf file.
 func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	azCredConfigBlock := cloudCredBlockBody.AppendNewBlock(azure.AzureCredentialConfig, nil)
-	azCredConfigBlockBody := azCredConfigBlock.Body()
-
-	azCredConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azCredConfigBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
-	azCredConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
-	azCredConfigBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
-
-	rootBody.AppendNewline()
+	setAzureCloudCredential(terraformConfig, rootBody)
 
 	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
 	clusterBlockBody := clusterBlock.Body()
@@ -72,15 +59,66 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 
 		nodePoolsBlockBody.SetAttributeRaw(azure.AvailabilityZones, availabilityZones)
 		nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(terraformConfig.AzureConfig.Mode))
-		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.AzureConfig.Name))
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(aksPoolName(terraformConfig, count)))
 		nodePoolsBlockBody.SetAttributeValue(azure.Count, cty.NumberIntVal(pool.Quantity))
-		nodePoolsBlockBody.SetAttributeValue(azure.OrchestratorVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+
+		if pool.MaxSize > 0 {
+			nodePoolsBlockBody.SetAttributeValue(azure.EnableAutoScaling, cty.BoolVal(true))
+			nodePoolsBlockBody.SetAttributeValue(azure.MaxCount, cty.NumberIntVal(pool.MaxSize))
+			nodePoolsBlockBody.SetAttributeValue(azure.MinCount, cty.NumberIntVal(pool.MinSize))
+		}
+
+		nodePoolsBlockBody.SetAttributeValue(azure.OrchestratorVersion, cty.StringVal(poolKubernetesVersion(terratestConfig, pool)))
 		nodePoolsBlockBody.SetAttributeValue(azure.OSDiskSizeGB, cty.NumberIntVal(terraformConfig.AzureConfig.OSDiskSizeGB))
-		nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(terraformConfig.AzureConfig.VMSize))
 
-		taints := format.ListOfStrings(terraformConfig.AzureConfig.Taints)
+		vmSize := terraformConfig.AzureConfig.VMSize
+		if pool.InstanceType != "" {
+			vmSize = pool.InstanceType
+		}
+
+		nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(vmSize))
+		setPoolLabels(nodePoolsBlockBody, azure.Labels, pool.Labels)
+
+		poolTaints := terraformConfig.AzureConfig.Taints
+		if len(pool.Taints) > 0 {
+			poolTaints = pool.Taints
+		}
+
+		taints := format.ListOfStrings(poolTaints)
 		nodePoolsBlockBody.SetAttributeRaw(azure.Taints, taints)
+
+		if pool.Spot {
+			setAKSPoolSpot(nodePoolsBlockBody)
+		}
 	}
 
 	return newFile, file, nil
 }
+
+// aksPoolName returns the name of the AKS node pool. AKS pool names must be unique and alphanumeric, so additional pools
+// are suffixed with their index.
+func aksPoolName(terraformConfig *config.TerraformConfig, count int) string {
+	if count == 0 {
+		return terraformConfig.AzureConfig.Name
+	}
+
+	return terraformConfig.AzureConfig.Name + strconv.Itoa(count)
+}
+
+// setAzureCloudCredential is a function that will set the Azure cloud credential in the main.tf file.
+func setAzureCloudCredential(terraformConfig *config.TerraformConfig, rootBody *hclwrite.Body) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	azCredConfigBlock := cloudCredBlockBody.AppendNewBlock(azure.AzureCredentialConfig, nil)
+	azCredConfigBlockBody := azCredConfigBlock.Body()
+
+	azCredConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
+	azCredConfigBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
+	azCredConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
+	azCredConfigBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
+
+	rootBody.AppendNewline()
+}
@@ -17,18 +17,7 @@ import (
 // SetEKS is a function that will set the EKS configurations in the main.tf file.
 func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	ec2CredConfigBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
-	ec2CredConfigBlockBody := ec2CredConfigBlock.Body()
-
-	ec2CredConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	ec2CredConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
-
-	rootBody.AppendNewline()
+	setAWSCloudCredential(terraformConfig, rootBody)
 
 	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
 	clusterBlockBody := clusterBlock.Body()
@@ -65,11 +54,42 @@ func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 
 		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
 		nodePoolsBlockBody.SetAttributeValue(amazon.DiskSize, cty.NumberIntVal(pool.DiskSize))
-		nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+
+		// Spot node groups take their instance types from spot_instance_types instead of instance_type.
+		if pool.Spot {
+			nodePoolsBlockBody.SetAttributeValue(amazon.RequestSpotInstances, cty.BoolVal(true))
+			nodePoolsBlockBody.SetAttributeRaw(amazon.SpotInstanceTypes, format.ListOfStrings([]string{pool.InstanceType}))
+		} else {
+			nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+		}
+
 		nodePoolsBlockBody.SetAttributeValue(amazon.DesiredSize, cty.NumberIntVal(pool.DesiredSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MaxSize, cty.NumberIntVal(pool.MaxSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MinSize, cty.NumberIntVal(pool.MinSize))
+		nodePoolsBlockBody.SetAttributeValue(amazon.Version, cty.StringVal(poolKubernetesVersion(terratestConfig, pool)))
+		setPoolLabels(nodePoolsBlockBody, amazon.Labels, pool.Labels)
+
+		err = setEKSPoolTaints(nodePoolsBlockBody, pool.Taints)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return newFile, file, nil
 }
+
+// setAWSCloudCredential is a function that will set the AWS cloud credential in the main.tf file.
+func setAWSCloudCredential(terraformConfig *config.TerraformConfig, rootBody *hclwrite.Body) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	ec2CredConfigBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
+	ec2CredConfigBlockBody := ec2CredConfigBlock.Body()
+
+	ec2CredConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
+	ec2CredConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+
+	rootBody.AppendNewline()
+}
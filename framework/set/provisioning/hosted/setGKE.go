@@ -16,15 +16,7 @@ import (
 // SetGKE is a function that will set the GKE configurations in the main.tf file.
 func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	googleCredConfigBlock := cloudCredBlockBody.AppendNewBlock(google.GoogleCredentialConfig, nil)
-	googleCredConfigBlock.Body().SetAttributeValue(google.AuthEncodedJSON, cty.StringVal(terraformConfig.GoogleCredentials.AuthEncodedJSON))
-
-	rootBody.AppendNewline()
+	setGoogleCloudCredential(terraformConfig, rootBody)
 
 	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
 	clusterBlockBody := clusterBlock.Body()
@@ -61,8 +53,49 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 		nodePoolsBlockBody.SetAttributeValue(google.InitialNodeCount, cty.NumberIntVal(pool.Quantity))
 		nodePoolsBlockBody.SetAttributeValue(google.MaxPodsConstraint, cty.NumberIntVal(pool.MaxPodsConstraint))
 		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
-		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(terratestConfig.KubernetesVersion))
+		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(poolKubernetesVersion(terratestConfig, pool)))
+
+		if pool.MaxSize > 0 {
+			autoscalingBlock := nodePoolsBlockBody.AppendNewBlock(google.Autoscaling, nil)
+			autoscalingBlockBody := autoscalingBlock.Body()
+
+			autoscalingBlockBody.SetAttributeValue(google.Enabled, cty.BoolVal(true))
+			autoscalingBlockBody.SetAttributeValue(google.MaxNodeCount, cty.NumberIntVal(pool.MaxSize))
+			autoscalingBlockBody.SetAttributeValue(google.MinNodeCount, cty.NumberIntVal(pool.MinSize))
+		}
+
+		if pool.InstanceType == "" && len(pool.Labels) == 0 && len(pool.Taints) == 0 && !pool.Spot {
+			continue
+		}
+
+		nodeConfigBlock := nodePoolsBlockBody.AppendNewBlock(google.NodeConfig, nil)
+		nodeConfigBlockBody := nodeConfigBlock.Body()
+
+		if pool.InstanceType != "" {
+			nodeConfigBlockBody.SetAttributeValue(google.MachineType, cty.StringVal(pool.InstanceType))
+		}
+
+		setPoolLabels(nodeConfigBlockBody, google.Labels, pool.Labels)
+		nodeConfigBlockBody.SetAttributeValue(google.Preemptible, cty.BoolVal(pool.Spot))
+
+		err = setGKEPoolTaints(nodeConfigBlockBody, pool.Taints)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return newFile, file, nil
 }
+
+// setGoogleCloudCredential is a function that will set the Google cloud credential in the main.tf file.
+func setGoogleCloudCredential(terraformConfig *config.TerraformConfig, rootBody *hclwrite.Body) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	googleCredConfigBlock := cloudCredBlockBody.AppendNewBlock(google.GoogleCredentialConfig, nil)
+	googleCredConfigBlock.Body().SetAttributeValue(google.AuthEncodedJSON, cty.StringVal(terraformConfig.GoogleCredentials.AuthEncodedJSON))
+
+	rootBody.AppendNewline()
+}
@@ -0,0 +1,45 @@
+package hosted
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedAKS is a function that will set the configurations to import an existing AKS cluster in the main.tf file.
+func SetImportedAKS(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	if terraformConfig.HostedImport == nil || terraformConfig.HostedImport.AKSClusterName == "" {
+		return nil, nil, fmt.Errorf("hostedImport.aksClusterName must be set for module: %v", terraformConfig.Module)
+	}
+
+	clusterName := terraformConfig.HostedImport.AKSClusterName
+
+	setAzureCloudCredential(terraformConfig, rootBody)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	aksConfigBlock := clusterBlockBody.AppendNewBlock(azure.AKSConfig, nil)
+	aksConfigBlockBody := aksConfigBlock.Body()
+
+	cloudCredID := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+	}
+
+	aksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
+	aksConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterName))
+	aksConfigBlockBody.SetAttributeValue(azure.ResourceGroup, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+	aksConfigBlockBody.SetAttributeValue(azure.ResourceLocation, cty.StringVal(terraformConfig.AzureConfig.ResourceLocation))
+	aksConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	return newFile, file, nil
+}
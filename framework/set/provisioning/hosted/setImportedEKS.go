@@ -0,0 +1,44 @@
+package hosted
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedEKS is a function that will set the configurations to import an existing EKS cluster in the main.tf file.
+func SetImportedEKS(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	if terraformConfig.HostedImport == nil || terraformConfig.HostedImport.EKSClusterName == "" {
+		return nil, nil, fmt.Errorf("hostedImport.eksClusterName must be set for module: %v", terraformConfig.Module)
+	}
+
+	clusterName := terraformConfig.HostedImport.EKSClusterName
+
+	setAWSCloudCredential(terraformConfig, rootBody)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	eksConfigBlock := clusterBlockBody.AppendNewBlock(amazon.EKSConfig, nil)
+	eksConfigBlockBody := eksConfigBlock.Body()
+
+	cloudCredID := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+	}
+
+	eksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
+	eksConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterName))
+	eksConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
+	eksConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	return newFile, file, nil
+}
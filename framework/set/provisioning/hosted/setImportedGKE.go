@@ -0,0 +1,45 @@
+package hosted
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedGKE is a function that will set the configurations to import an existing GKE cluster in the main.tf file.
+func SetImportedGKE(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	if terraformConfig.HostedImport == nil || terraformConfig.HostedImport.GKEClusterName == "" {
+		return nil, nil, fmt.Errorf("hostedImport.gkeClusterName must be set for module: %v", terraformConfig.Module)
+	}
+
+	clusterName := terraformConfig.HostedImport.GKEClusterName
+
+	setGoogleCloudCredential(terraformConfig, rootBody)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	gkeConfigBlock := clusterBlockBody.AppendNewBlock(google.GKEConfig, nil)
+	gkeConfigBlockBody := gkeConfigBlock.Body()
+
+	cloudCredSecret := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+	}
+
+	gkeConfigBlockBody.SetAttributeRaw(google.GoogleCredentialSecret, cloudCredSecret)
+	gkeConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterName))
+	gkeConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.GoogleConfig.Region))
+	gkeConfigBlockBody.SetAttributeValue(google.ProjectID, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+	gkeConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	return newFile, file, nil
+}
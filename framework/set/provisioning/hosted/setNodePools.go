@@ -0,0 +1,121 @@
+package hosted
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/zclconf/go-cty/cty"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GKE expects the taint effects in the format of its API rather than the Kubernetes one.
+var gkeTaintEffects = map[corev1.TaintEffect]string{
+	corev1.TaintEffectNoSchedule:       "NO_SCHEDULE",
+	corev1.TaintEffectPreferNoSchedule: "PREFER_NO_SCHEDULE",
+	corev1.TaintEffectNoExecute:        "NO_EXECUTE",
+}
+
+const (
+	aksSpotEvictionPolicy = "Delete"
+	aksSpotMode           = "User"
+	aksSpotPriority       = "Spot"
+
+	// eksTaintsUserData is the MIME multipart user data of an EKS node group registering its nodes with the given taints. EKS
+	// merges the NodeConfig with its own, so the kubelet flags are added to the bootstrap of the node. Only AL2023 nodes, the
+	// default AMI of EKS node groups from Kubernetes 1.30 on, read a NodeConfig.
+	eksTaintsUserData = `MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="BOUNDARY"
+
+--BOUNDARY
+Content-Type: application/node.eks.aws
+
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  kubelet:
+    flags:
+      - --register-with-taints=%s
+
+--BOUNDARY--`
+)
+
+// poolKubernetesVersion returns the Kubernetes version of the pool, falling back to the version of the cluster.
+func poolKubernetesVersion(terratestConfig *config.TerratestConfig, pool config.Nodepool) string {
+	if pool.KubernetesVersion != "" {
+		return pool.KubernetesVersion
+	}
+
+	return terratestConfig.KubernetesVersion
+}
+
+// setPoolLabels sets the labels of the pool on the given block, if any are specified.
+func setPoolLabels(blockBody *hclwrite.Body, name string, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	values := map[string]cty.Value{}
+	for key, value := range labels {
+		values[key] = cty.StringVal(value)
+	}
+
+	blockBody.SetAttributeValue(name, cty.MapVal(values))
+}
+
+// setGKEPoolTaints sets a taints block per taint of the pool on the given GKE node config block.
+func setGKEPoolTaints(nodeConfigBlockBody *hclwrite.Body, taints []string) error {
+	for _, taint := range taints {
+		parsedTaint, err := format.ParseTaint(taint)
+		if err != nil {
+			return err
+		}
+
+		taintsBlock := nodeConfigBlockBody.AppendNewBlock(google.Taints, nil)
+		taintsBlockBody := taintsBlock.Body()
+
+		taintsBlockBody.SetAttributeValue(google.TaintEffect, cty.StringVal(gkeTaintEffects[parsedTaint.Effect]))
+		taintsBlockBody.SetAttributeValue(google.TaintKey, cty.StringVal(parsedTaint.Key))
+		taintsBlockBody.SetAttributeValue(google.TaintValue, cty.StringVal(parsedTaint.Value))
+	}
+
+	return nil
+}
+
+// setEKSPoolTaints sets the user data of the EKS node group to register its nodes with the taints of the pool, as EKS node groups
+// managed by Rancher do not take taints.
+func setEKSPoolTaints(nodeGroupBlockBody *hclwrite.Body, taints []string) error {
+	if len(taints) == 0 {
+		return nil
+	}
+
+	for _, taint := range taints {
+		_, err := format.ParseTaint(taint)
+		if err != nil {
+			return err
+		}
+	}
+
+	userData := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + fmt.Sprintf(eksTaintsUserData, strings.Join(taints, ",")) + "\nEOF"},
+	})
+
+	nodeGroupBlockBody.SetAttributeRaw(amazon.UserData, userData)
+
+	return nil
+}
+
+// setAKSPoolSpot sets the scale set priority of a spot AKS node pool. Spot pools can only be user pools, and their nodes are
+// deleted on eviction.
+func setAKSPoolSpot(nodePoolsBlockBody *hclwrite.Body) {
+	nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(aksSpotMode))
+	nodePoolsBlockBody.SetAttributeValue(azure.ScaleSetPriority, cty.StringVal(aksSpotPriority))
+	nodePoolsBlockBody.SetAttributeValue(azure.ScaleSetEviction, cty.StringVal(aksSpotEvictionPolicy))
+}
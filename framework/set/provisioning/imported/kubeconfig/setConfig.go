@@ -0,0 +1,87 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	importCluster       = "import_cluster"
+	kubeconfigEnvVar    = "KUBECONFIG"
+	kubeconfigExtension = "-kubeconfig.yaml"
+)
+
+// SetImportedKubeconfig is a function that will set the configurations to import an existing cluster, reachable with the
+// given kubeconfig, in the main.tf file.
+func SetImportedKubeconfig(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	kubeconfigPath, err := getKubeconfigPath(terraformConfig, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imported.SetImportedCluster(rootBody, terraformConfig.ResourcePrefix)
+	rootBody.AppendNewline()
+
+	importClusterName := terraformConfig.ResourcePrefix + `_` + importCluster
+
+	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, importClusterName})
+	nullResourceBlockBody := nullResourceBlock.Body()
+
+	provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.LocalExec})
+	provisionerBlockBody := provisionerBlock.Body()
+
+	// The insecure command pipes the registration manifest into kubectl, which targets the cluster set by KUBECONFIG.
+	importCommandExpression := fmt.Sprintf("%s.%s.%s[0].%s", defaults.Cluster, terraformConfig.ResourcePrefix, defaults.ClusterRegistrationToken,
+		defaults.InsecureCommand)
+	provisionerBlockBody.SetAttributeRaw(defaults.Command, hclwrite.TokensForIdentifier(importCommandExpression))
+
+	provisionerBlockBody.SetAttributeValue(defaults.Environment, cty.MapVal(map[string]cty.Value{
+		kubeconfigEnvVar: cty.StringVal(kubeconfigPath),
+	}))
+
+	dependsOnCluster := `[` + defaults.Cluster + `.` + terraformConfig.ResourcePrefix + `]`
+	cluster := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnCluster)},
+	}
+
+	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, cluster)
+
+	rootBody.AppendNewline()
+
+	return newFile, file, nil
+}
+
+// getKubeconfigPath returns the kubeconfig path of the cluster to import. Inline kubeconfigs are written next to the main.tf file.
+func getKubeconfigPath(terraformConfig *config.TerraformConfig, file *os.File) (string, error) {
+	if terraformConfig.ImportKubeconfig == nil {
+		return "", fmt.Errorf("importKubeconfig must be set for module: %v", terraformConfig.Module)
+	}
+
+	if terraformConfig.ImportKubeconfig.KubeconfigPath != "" {
+		return filepath.Abs(terraformConfig.ImportKubeconfig.KubeconfigPath)
+	}
+
+	if terraformConfig.ImportKubeconfig.KubeconfigContent == "" {
+		return "", fmt.Errorf("kubeconfigPath or kubeconfigContent must be set for module: %v", terraformConfig.Module)
+	}
+
+	kubeconfigPath := filepath.Join(filepath.Dir(file.Name()), terraformConfig.ResourcePrefix+kubeconfigExtension)
+
+	err := os.WriteFile(kubeconfigPath, []byte(terraformConfig.ImportKubeconfig.KubeconfigContent), 0600)
+	if err != nil {
+		logrus.Infof("Failed to write kubeconfig to %s. Error: %v", kubeconfigPath, err)
+		return "", err
+	}
+
+	return kubeconfigPath, nil
+}
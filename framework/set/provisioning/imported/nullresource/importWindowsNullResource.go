@@ -1,19 +1,18 @@
 package nullresource
 
 import (
-	"strings"
-
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CreateImportedWindowsNullResource is a helper function that will create the null_resource for the Windows node.
 func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	publicDNS, resourceName string) (*hclwrite.Body, *hclwrite.Body) {
+	publicDNS, resourceName string) (*hclwrite.Body, *hclwrite.Body, error) {
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, resourceName})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
@@ -40,16 +39,17 @@ func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.WinRM))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
-	if strings.Contains(terraformConfig.Module, modules.ImportEC2RKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, modules.ImportEC2RKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
+	windowsVersion, err := format.WindowsVersion(terraformConfig.Module, terratestConfig.WindowsOSVersion)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(aws.WindowsPassword(terraformConfig, windowsVersion)))
+
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
 
 	connectionBlockBody.SetAttributeValue(defaults.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
 
-	return nullResourceBlockBody, provisionerBlockBody
+	return nullResourceBlockBody, provisionerBlockBody, nil
 }
@@ -9,6 +9,7 @@ import (
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/sleep"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
@@ -48,7 +49,12 @@ func SetImportedRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig
 	rootBody.AppendNewline()
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		windowsVersion, err := format.WindowsVersion(terraformConfig.Module, terratestConfig.WindowsOSVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix, windowsVersion)
 		rootBody.AppendNewline()
 
 		windowsNodePublicDNS := fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, windowsNodeName)
@@ -0,0 +1,26 @@
+package rke1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
+	vsphere "github.com/rancher/tfp-automation/framework/set/provisioning/providers/vsphere"
+)
+
+// setCloudProvider is a function that will set the cloud provider configurations in the main.tf file.
+func setCloudProvider(rootBody, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	switch {
+	case terraformConfig.CloudProvider == cloudproviders.AWS && terraformConfig.Module == modules.EC2RKE1:
+		aws.SetAWSRKE1CloudProvider(rootBody, rkeConfigBlockBody, terraformConfig)
+	case terraformConfig.CloudProvider == cloudproviders.RancherVsphere && terraformConfig.Module == modules.VsphereRKE1:
+		vsphere.SetVsphereRKE1CloudProvider(rkeConfigBlockBody, terraformConfig)
+	default:
+		return fmt.Errorf("Unsupported cloud provider %v for module: %v", terraformConfig.CloudProvider, terraformConfig.Module)
+	}
+
+	return nil
+}
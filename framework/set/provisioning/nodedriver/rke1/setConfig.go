@@ -11,6 +11,7 @@ import (
 	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
 	azure "github.com/rancher/tfp-automation/framework/set/provisioning/providers/azure"
+	digitalocean "github.com/rancher/tfp-automation/framework/set/provisioning/providers/digitalocean"
 	harvester "github.com/rancher/tfp-automation/framework/set/provisioning/providers/harvester"
 	linode "github.com/rancher/tfp-automation/framework/set/provisioning/providers/linode"
 	vsphere "github.com/rancher/tfp-automation/framework/set/provisioning/providers/vsphere"
@@ -31,6 +32,7 @@ const (
 	snapshot                = "snapshot"
 	s3BackupConfig          = "s3_backup_config"
 	bucketName              = "bucket_name"
+	customCA                = "custom_ca"
 	privateRegistryURL      = "url"
 	privateRegistryUsername = "user"
 	privateRegistryPassword = "password"
@@ -59,6 +61,8 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 	}
 
 	switch terraformConfig.Module {
+	case modules.DORKE1:
+		digitalocean.SetDigitalOceanRKE1Provider(nodeTemplateBlockBody, terraformConfig)
 	case modules.EC2RKE1:
 		aws.SetAWSRKE1Provider(nodeTemplateBlockBody, terraformConfig)
 	case modules.AzureRKE1:
@@ -72,9 +76,19 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 		vsphere.SetVsphereRKE1Provider(nodeTemplateBlockBody, terraformConfig)
 	}
 
+	psact := terratestConfig.PSACT
+	if terraformConfig.Hardened {
+		err := v2.SetHardenedUserData(nodeTemplateBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		psact = string(config.RancherRestricted)
+	}
+
 	rootBody.AppendNewline()
 
-	if strings.Contains(terratestConfig.PSACT, defaults.RancherBaseline) {
+	if strings.Contains(psact, defaults.RancherBaseline) {
 		rootBody, err := resources.SetBaselinePSACT(newFile, rootBody, terraformConfig.ResourcePrefix)
 		if err != nil {
 			return nil, nil, err
@@ -83,7 +97,7 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 		rootBody.AppendNewline()
 	}
 
-	clusterBlockBody, err := setClusterConfig(rootBody, terraformConfig, terratestConfig.PSACT)
+	clusterBlockBody, err := setClusterConfig(rootBody, terraformConfig, psact)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,6 +114,13 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 		return nil, nil, err
 	}
 
+	if terraformConfig.CloudProvider != "" {
+		err = setCloudProvider(rootBody, rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	rootBody.AppendNewline()
 
 	if terraformConfig.PrivateRegistries != nil && strings.Contains(terraformConfig.Module, modules.EC2) {
@@ -112,7 +133,34 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 	}
 
 	if terraformConfig.ETCDRKE1 != nil {
-		err = setEtcdConfig(rkeConfigBlockBody, terraformConfig)
+		err = SetEtcdConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	if terraformConfig.SecretsEncryption != nil {
+		err = SetSecretsEncryptionConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	if terraformConfig.Hardened {
+		err = SetHardenedConfig(rkeConfigBlockBody)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	if terratestConfig.CertRotationInput != nil {
+		err = SetRotateCertificates(rkeConfigBlockBody, terratestConfig)
 		if err != nil {
 			return nil, nil, err
 		}
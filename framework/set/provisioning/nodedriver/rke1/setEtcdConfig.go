@@ -3,18 +3,16 @@ package rke1
 import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
 
-// setEtcdConfig is a function that will set the etcd configurations in the main.tf file.
-func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
-	servicesBlock := rkeConfigBlockBody.AppendNewBlock(defaults.Services, nil)
-	servicesBlockBody := servicesBlock.Body()
+// SetEtcdConfig is a function that will set the etcd configurations in the main.tf file. When an S3 backup target is configured,
+// snapshots are uploaded to it regardless of the provider the cluster is created on.
+func SetEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	servicesBlockBody := servicesBlock(rkeConfigBlockBody)
 
-	etcdBlock := servicesBlockBody.AppendNewBlock(defaults.Etcd, nil)
-	etcdBlockBody := etcdBlock.Body()
+	etcdBlockBody := childBlock(servicesBlockBody, defaults.Etcd)
 
 	backupConfigBlock := etcdBlockBody.AppendNewBlock(backupConfig, nil)
 	backupConfigBlockBody := backupConfigBlock.Body()
@@ -24,12 +22,17 @@ func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.Te
 	backupConfigBlockBody.SetAttributeValue(safeTimestamp, cty.BoolVal(terraformConfig.ETCDRKE1.BackupConfig.SafeTimestamp))
 	backupConfigBlockBody.SetAttributeValue(timeout, cty.NumberIntVal(terraformConfig.ETCDRKE1.BackupConfig.Timeout))
 
-	if terraformConfig.Module == modules.EC2RKE1 && terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig != nil {
+	if terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig != nil {
 		s3ConfigBlock := backupConfigBlockBody.AppendNewBlock(s3BackupConfig, nil)
 		s3ConfigBlockBody := s3ConfigBlock.Body()
 
 		s3ConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.AccessKey))
 		s3ConfigBlockBody.SetAttributeValue(bucketName, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.BucketName))
+
+		if terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.CustomCA != "" {
+			s3ConfigBlockBody.SetAttributeValue(customCA, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.CustomCA))
+		}
+
 		s3ConfigBlockBody.SetAttributeValue(defaults.Endpoint, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Endpoint))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Folder))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Region))
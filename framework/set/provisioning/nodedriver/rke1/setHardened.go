@@ -0,0 +1,44 @@
+package rke1
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	auditLog                   = "audit_log"
+	eventRateLimit             = "event_rate_limit"
+	extraArgs                  = "extra_args"
+	generateServingCertificate = "generate_serving_certificate"
+	gid                        = "gid"
+	kubelet                    = "kubelet"
+	uid                        = "uid"
+
+	// The etcd user and group are created with this ID by the hardened node user-data.
+	etcdUserID = 52034
+)
+
+// SetHardenedConfig is a function that will set the services configurations required by the RKE1 CIS hardened profile in the
+// main.tf file. The configurations are merged into the services blocks that have already been set.
+func SetHardenedConfig(rkeConfigBlockBody *hclwrite.Body) error {
+	servicesBlockBody := servicesBlock(rkeConfigBlockBody)
+
+	etcdBlockBody := childBlock(servicesBlockBody, defaults.Etcd)
+	etcdBlockBody.SetAttributeValue(gid, cty.NumberIntVal(etcdUserID))
+	etcdBlockBody.SetAttributeValue(uid, cty.NumberIntVal(etcdUserID))
+
+	kubeAPIBlockBody := childBlock(servicesBlockBody, kubeAPI)
+	childBlock(kubeAPIBlockBody, auditLog).SetAttributeValue(defaults.Enabled, cty.BoolVal(true))
+	childBlock(kubeAPIBlockBody, eventRateLimit).SetAttributeValue(defaults.Enabled, cty.BoolVal(true))
+	childBlock(kubeAPIBlockBody, secretsEncryptionConfig).SetAttributeValue(defaults.Enabled, cty.BoolVal(true))
+
+	kubeletBlockBody := childBlock(servicesBlockBody, kubelet)
+	kubeletBlockBody.SetAttributeValue(extraArgs, cty.MapVal(map[string]cty.Value{
+		"feature-gates":           cty.StringVal("RotateKubeletServerCertificate=true"),
+		"protect-kernel-defaults": cty.StringVal("true"),
+	}))
+	kubeletBlockBody.SetAttributeValue(generateServingCertificate, cty.BoolVal(true))
+
+	return nil
+}
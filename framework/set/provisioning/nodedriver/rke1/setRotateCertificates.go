@@ -0,0 +1,33 @@
+package rke1
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	caCertificates = "ca_certificates"
+)
+
+// SetRotateCertificates is a function that will set the rotate_certificates block in the main.tf file for a RKE1 cluster.
+// RKE1 has no generation, so certificates are rotated when the block is added or changed.
+func SetRotateCertificates(rkeConfigBlockBody *hclwrite.Body, terratestConfig *config.TerratestConfig) error {
+	rotateCertificatesBlock := rkeConfigBlockBody.AppendNewBlock(v2.RotateCertificates, nil)
+	rotateCertificatesBlockBody := rotateCertificatesBlock.Body()
+
+	rotateCertificatesBlockBody.SetAttributeValue(caCertificates, cty.BoolVal(terratestConfig.CertRotationInput.CACertificates))
+
+	if len(terratestConfig.CertRotationInput.Services) > 0 {
+		var services []cty.Value
+		for _, service := range terratestConfig.CertRotationInput.Services {
+			services = append(services, cty.StringVal(service))
+		}
+
+		rotateCertificatesBlockBody.SetAttributeValue(defaults.Services, cty.ListVal(services))
+	}
+
+	return nil
+}
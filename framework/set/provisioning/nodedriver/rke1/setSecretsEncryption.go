@@ -0,0 +1,41 @@
+package rke1
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	kubeAPI                 = "kube_api"
+	secretsEncryptionConfig = "secrets_encryption_config"
+)
+
+// SetSecretsEncryptionConfig is a function that will set the secrets encryption configurations in the main.tf file for a RKE1 cluster.
+// The encryption key of a RKE1 cluster is rotated through the rotateEncryptionKey action of the management API.
+func SetSecretsEncryptionConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	servicesBlockBody := servicesBlock(rkeConfigBlockBody)
+
+	kubeAPIBlockBody := childBlock(servicesBlockBody, kubeAPI)
+	secretsEncryptionConfigBlockBody := childBlock(kubeAPIBlockBody, secretsEncryptionConfig)
+
+	secretsEncryptionConfigBlockBody.SetAttributeValue(defaults.Enabled, cty.BoolVal(terraformConfig.SecretsEncryption.Enabled))
+
+	return nil
+}
+
+// servicesBlock is a function that will return the services block of the RKE config, creating it if it has not been set yet.
+func servicesBlock(rkeConfigBlockBody *hclwrite.Body) *hclwrite.Body {
+	return childBlock(rkeConfigBlockBody, defaults.Services)
+}
+
+// childBlock is a function that will return the first block of the given type in the body, creating it if it has not been set yet.
+func childBlock(body *hclwrite.Body, blockType string) *hclwrite.Body {
+	block := body.FirstMatchingBlock(blockType, nil)
+	if block == nil {
+		block = body.AppendNewBlock(blockType, nil)
+	}
+
+	return block.Body()
+}
@@ -0,0 +1,31 @@
+package rke2k3s
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
+	harvester "github.com/rancher/tfp-automation/framework/set/provisioning/providers/harvester"
+)
+
+// setCloudProvider is a function that will set the cloud provider configurations in the main.tf file. The
+// cloud-provider-name and the cloud provider chart values are set as part of the RKE configurations. K3s has no
+// in-tree cloud providers, so cloud providers are only supported for RKE2.
+func setCloudProvider(rootBody, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	switch {
+	case terraformConfig.CloudProvider == cloudproviders.AWS && terraformConfig.Module == modules.EC2RKE2:
+		aws.SetAWSRKE2K3SCloudProvider(rkeConfigBlockBody)
+	case terraformConfig.CloudProvider == cloudproviders.Harvester && terraformConfig.Module == modules.HarvesterRKE2:
+		rootBody.AppendNewline()
+		harvester.SetHarvesterRKE2CloudProvider(rootBody, rkeConfigBlockBody, terraformConfig)
+	case terraformConfig.CloudProvider == cloudproviders.RancherVsphere && terraformConfig.Module == modules.VsphereRKE2:
+		return nil
+	default:
+		return fmt.Errorf("Unsupported cloud provider %v for module: %v", terraformConfig.CloudProvider, terraformConfig.Module)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
 	azure "github.com/rancher/tfp-automation/framework/set/provisioning/providers/azure"
+	digitalocean "github.com/rancher/tfp-automation/framework/set/provisioning/providers/digitalocean"
 	harvester "github.com/rancher/tfp-automation/framework/set/provisioning/providers/harvester"
 	linode "github.com/rancher/tfp-automation/framework/set/provisioning/providers/linode"
 	vsphere "github.com/rancher/tfp-automation/framework/set/provisioning/providers/vsphere"
@@ -48,6 +49,8 @@ const (
 	endpoints             = "endpoints"
 	rewrites              = "rewrites"
 
+	cloudProviderName = "cloud-provider-name"
+
 	httpProxy    = "HTTP_PROXY"
 	httpsProxy   = "HTTPS_PROXY"
 	noProxy      = "NO_PROXY"
@@ -58,6 +61,8 @@ const (
 func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File, rbacRole config.Role) (*hclwrite.File, *os.File, error) {
 	switch terraformConfig.Module {
+	case modules.DORKE2, modules.DOK3s:
+		digitalocean.SetDigitalOceanRKE2K3SProvider(rootBody, terraformConfig)
 	case modules.EC2RKE2, modules.EC2K3s:
 		aws.SetAWSRKE2K3SProvider(rootBody, terraformConfig)
 	case modules.AzureRKE2, modules.AzureK3s:
@@ -72,7 +77,12 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 
 	rootBody.AppendNewline()
 
-	if strings.Contains(terratestConfig.PSACT, defaults.RancherBaseline) {
+	psact := terratestConfig.PSACT
+	if terraformConfig.Hardened {
+		psact = string(config.RancherRestricted)
+	}
+
+	if strings.Contains(psact, defaults.RancherBaseline) {
 		rootBody, err := resources.SetBaselinePSACT(newFile, rootBody, terraformConfig.ResourcePrefix)
 		if err != nil {
 			return nil, nil, err
@@ -81,12 +91,14 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		rootBody.AppendNewline()
 	}
 
-	machineConfigBlockBody, err := setMachineConfig(rootBody, terraformConfig, terratestConfig.PSACT)
+	machineConfigBlockBody, err := setMachineConfig(rootBody, terraformConfig, psact)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	switch terraformConfig.Module {
+	case modules.DORKE2, modules.DOK3s:
+		digitalocean.SetDigitalOceanRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
 	case modules.EC2RKE2, modules.EC2K3s:
 		aws.SetAWSRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
 	case modules.AzureRKE2, modules.AzureK3s:
@@ -99,9 +111,16 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		vsphere.SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
 	}
 
+	if terraformConfig.Hardened {
+		err = SetHardenedUserData(machineConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	rootBody.AppendNewline()
 
-	clusterBlockBody, err := setClusterConfig(rootBody, terraformConfig, terratestConfig.PSACT, terratestConfig.KubernetesVersion)
+	clusterBlockBody, err := setClusterConfig(rootBody, terraformConfig, psact, terratestConfig.KubernetesVersion)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -125,6 +144,13 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		}
 	}
 
+	if terraformConfig.CloudProvider != "" {
+		err = setCloudProvider(rootBody, rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if terraformConfig.PrivateRegistries != nil && strings.Contains(terraformConfig.Module, modules.EC2) {
 		if terraformConfig.PrivateRegistries.Username != "" {
 			rootBody.AppendNewline()
@@ -145,7 +171,21 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 	}
 
 	if terraformConfig.ETCD != nil {
-		err = setEtcdConfig(rkeConfigBlockBody, terraformConfig)
+		err = SetEtcdConfig(rootBody, rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if terraformConfig.SecretsEncryption != nil && terraformConfig.SecretsEncryption.RotateEncryptionKeysGeneration > 0 {
+		err = SetRotateEncryptionKeys(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if terratestConfig.CertRotationInput != nil {
+		err = SetRotateCertificates(rkeConfigBlockBody, terratestConfig)
 		if err != nil {
 			return nil, nil, err
 		}
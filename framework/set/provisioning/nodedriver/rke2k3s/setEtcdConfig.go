@@ -1,6 +1,7 @@
 package rke2k3s
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -11,8 +12,20 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-// setEtcdConfig is a function that will set the etcd configurations in the main.tf file.
-func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+const (
+	s3CredentialConfig = "s3_credential_config"
+	defaultBucket      = "default_bucket"
+	defaultEndpoint    = "default_endpoint"
+	defaultEndpointCA  = "default_endpoint_ca"
+	defaultFolder      = "default_folder"
+	defaultRegion      = "default_region"
+	defaultSkipSSL     = "default_skip_ssl_verify"
+	s3Suffix           = "-s3"
+)
+
+// SetEtcdConfig is a function that will set the etcd configurations in the main.tf file. When an S3 target is configured,
+// snapshots are uploaded to it regardless of the provider the cluster is created on.
+func SetEtcdConfig(rootBody, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	snapshotBlock := rkeConfigBlockBody.AppendNewBlock(defaults.Etcd, nil)
 	snapshotBlockBody := snapshotBlock.Body()
 
@@ -20,14 +33,15 @@ func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.Te
 	snapshotBlockBody.SetAttributeValue(snapshotScheduleCron, cty.StringVal(terraformConfig.ETCD.SnapshotScheduleCron))
 	snapshotBlockBody.SetAttributeValue(snapshotRetention, cty.NumberIntVal(int64(terraformConfig.ETCD.SnapshotRetention)))
 
-	if strings.Contains(terraformConfig.Module, modules.EC2) && terraformConfig.ETCD.S3 != nil {
+	if terraformConfig.ETCD.S3 != nil {
+		cloudCredSecretName, err := setS3CloudCredential(rootBody, terraformConfig)
+		if err != nil {
+			return err
+		}
+
 		s3ConfigBlock := snapshotBlockBody.AppendNewBlock(s3Config, nil)
 		s3ConfigBlockBody := s3ConfigBlock.Body()
 
-		cloudCredSecretName := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
-		}
-
 		s3ConfigBlockBody.SetAttributeValue(bucket, cty.StringVal(terraformConfig.ETCD.S3.Bucket))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Endpoint, cty.StringVal(terraformConfig.ETCD.S3.Endpoint))
 		s3ConfigBlockBody.SetAttributeRaw(cloudCredentialName, cloudCredSecretName)
@@ -39,3 +53,50 @@ func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.Te
 
 	return nil
 }
+
+// setS3CloudCredential is a function that will return the cloud credential used to upload etcd snapshots. An existing cloud credential
+// takes precedence, followed by a dedicated S3 cloud credential and lastly the AWS cloud credential of EC2 node driver clusters.
+func setS3CloudCredential(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) (hclwrite.Tokens, error) {
+	if terraformConfig.ETCD.S3.CloudCredentialName != "" {
+		return hclwrite.TokensForValue(cty.StringVal(terraformConfig.ETCD.S3.CloudCredentialName)), nil
+	}
+
+	if terraformConfig.ETCDS3Credentials != nil {
+		s3CloudCredential := terraformConfig.ResourcePrefix + s3Suffix
+
+		cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, s3CloudCredential})
+		cloudCredBlockBody := cloudCredBlock.Body()
+
+		cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(s3CloudCredential))
+
+		s3CredBlock := cloudCredBlockBody.AppendNewBlock(s3CredentialConfig, nil)
+		s3CredBlockBody := s3CredBlock.Body()
+
+		s3CredBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.ETCDS3Credentials.AccessKey))
+		s3CredBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.ETCDS3Credentials.SecretKey))
+		s3CredBlockBody.SetAttributeValue(defaultBucket, cty.StringVal(terraformConfig.ETCD.S3.Bucket))
+		s3CredBlockBody.SetAttributeValue(defaultEndpoint, cty.StringVal(terraformConfig.ETCD.S3.Endpoint))
+		s3CredBlockBody.SetAttributeValue(defaultEndpointCA, cty.StringVal(terraformConfig.ETCD.S3.EndpointCA))
+		s3CredBlockBody.SetAttributeValue(defaultFolder, cty.StringVal(terraformConfig.ETCD.S3.Folder))
+		s3CredBlockBody.SetAttributeValue(defaultRegion, cty.StringVal(terraformConfig.ETCD.S3.Region))
+		s3CredBlockBody.SetAttributeValue(defaultSkipSSL, cty.BoolVal(terraformConfig.ETCD.S3.SkipSSLVerify))
+
+		rootBody.AppendNewline()
+
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + s3CloudCredential + ".id")},
+		}, nil
+	}
+
+	// Only node driver clusters declare the AWS cloud credential, custom EC2 clusters must set one of the S3 credentials.
+	isNodeDriver := !strings.Contains(terraformConfig.Module, defaults.Custom) && !strings.Contains(terraformConfig.Module, defaults.Import) &&
+		!strings.Contains(terraformConfig.Module, defaults.Airgap)
+
+	if isNodeDriver && strings.HasPrefix(terraformConfig.Module, modules.EC2) {
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("etcdS3Credentials or etcd.s3.cloudCredentialName must be set for module: %v", terraformConfig.Module)
+}
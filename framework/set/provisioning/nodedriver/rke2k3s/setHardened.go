@@ -0,0 +1,189 @@
+package rke2k3s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/digitalocean"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/harvester"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	rke2HardenedConfig = "profile: cis\nprotect-kernel-defaults: true"
+	k3sHardenedConfig  = "protect-kernel-defaults: true\n" +
+		"kube-apiserver-arg:\n" +
+		"  - audit-policy-file=/var/lib/rancher/k3s/server/audit.yaml\n" +
+		"  - audit-log-path=/var/lib/rancher/k3s/server/logs/audit.log\n" +
+		"  - audit-log-maxage=30\n" +
+		"  - audit-log-maxbackup=10\n" +
+		"  - audit-log-maxsize=100\n" +
+		"  - request-timeout=300s\n" +
+		"  - service-account-lookup=true\n" +
+		"kubelet-arg:\n" +
+		"  - make-iptables-util-chains=true"
+
+	hardenedSysctls = `  - path: /etc/sysctl.d/90-kubelet.conf
+    permissions: '0644'
+    content: |
+      vm.panic_on_oom=0
+      vm.overcommit_memory=1
+      kernel.panic=10
+      kernel.panic_on_oops=1
+      kernel.keys.root_maxbytes=25000000`
+	k3sAuditPolicy = `  - path: /var/lib/rancher/k3s/server/audit.yaml
+    permissions: '0600'
+    content: |
+      apiVersion: audit.k8s.io/v1
+      kind: Policy
+      rules:
+        - level: Metadata`
+	qemuGuestAgent = "package_update: true\npackages:\n  - qemu-guest-agent"
+
+	rke1EtcdUser    = "  - groupadd --gid 52034 etcd\n  - useradd --comment 'etcd service account' --uid 52034 --gid 52034 --shell /sbin/nologin etcd"
+	rke2EtcdUser    = "  - useradd -r -c 'etcd user' -s /sbin/nologin -M etcd -U"
+	reloadSysctls   = "  - sysctl -p /etc/sysctl.d/90-kubelet.conf"
+	startQemuAgent  = "  - systemctl enable --now qemu-guest-agent.service"
+	cloudConfigHead = "#cloud-config"
+)
+
+// HardenedConfig is a function that will return the machine global config required by the CIS profile of the cluster. Secrets
+// encryption of K3S clusters is set by SecretsEncryptionConfig.
+func HardenedConfig(terraformConfig *config.TerraformConfig) string {
+	if !terraformConfig.Hardened {
+		return ""
+	}
+
+	if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		return k3sHardenedConfig
+	}
+
+	return rke2HardenedConfig
+}
+
+// SetHardenedUserData is a function that will set the node user-data required by the CIS profile on the provider block of the
+// machine config or node template in the main.tf file. The hardened user-data is merged with the user-data set in the config.
+func SetHardenedUserData(blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	var providerBlock *hclwrite.Block
+	var userDataName, configUserData string
+
+	switch {
+	case strings.Contains(terraformConfig.Module, modules.EC2):
+		providerBlock, userDataName = blockBody.FirstMatchingBlock(amazon.EC2Config, nil), amazon.Userdata
+	case strings.Contains(terraformConfig.Module, modules.DO):
+		providerBlock, userDataName = blockBody.FirstMatchingBlock(digitalocean.DigitalOceanConfig, nil), digitalocean.Userdata
+		configUserData = terraformConfig.DigitalOceanConfig.Userdata
+	case strings.Contains(terraformConfig.Module, modules.Azure):
+		providerBlock, userDataName = blockBody.FirstMatchingBlock(azure.AzureConfig, nil), azure.CustomData
+		configUserData = terraformConfig.AzureConfig.CustomData
+	case strings.Contains(terraformConfig.Module, modules.Harvester):
+		providerBlock, userDataName = blockBody.FirstMatchingBlock(harvester.HarvesterConfig, nil), harvester.UserData
+		configUserData = terraformConfig.HarvesterConfig.UserData
+	case strings.Contains(terraformConfig.Module, modules.Vsphere):
+		providerBlock, userDataName = blockBody.FirstMatchingBlock(vsphere.VsphereConfig, nil), vsphere.CloudConfig
+		configUserData = terraformConfig.VsphereConfig.CloudConfig
+	}
+
+	if providerBlock == nil {
+		return fmt.Errorf("Unsupported module for hardened clusters: %v", terraformConfig.Module)
+	}
+
+	hardenedUserData, err := mergeUserData(configUserData, HardenedUserData(terraformConfig))
+	if err != nil {
+		return err
+	}
+
+	userData := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + hardenedUserData + "\nEOF"},
+	})
+
+	providerBlock.Body().SetAttributeRaw(userDataName, userData)
+
+	return nil
+}
+
+// mergeUserData is a function that will merge the hardened cloud-config into the cloud-config set in the config. The lists of the
+// hardened cloud-config, e.g. write_files and runcmd, are appended to the ones of the config, and any other key of the config
+// is kept as is.
+func mergeUserData(configUserData, hardenedUserData string) (string, error) {
+	if strings.TrimSpace(configUserData) == "" {
+		return hardenedUserData, nil
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(configUserData), cloudConfigHead) {
+		return "", fmt.Errorf("The user-data of hardened clusters must be a cloud-config starting with %v", cloudConfigHead)
+	}
+
+	userData := map[string]any{}
+	err := yaml.Unmarshal([]byte(configUserData), &userData)
+	if err != nil {
+		return "", err
+	}
+
+	hardened := map[string]any{}
+	err = yaml.Unmarshal([]byte(hardenedUserData), &hardened)
+	if err != nil {
+		return "", err
+	}
+
+	for key, hardenedValue := range hardened {
+		configValue, ok := userData[key]
+		if !ok {
+			userData[key] = hardenedValue
+			continue
+		}
+
+		configList, isConfigList := configValue.([]any)
+		hardenedList, isHardenedList := hardenedValue.([]any)
+		if isConfigList && isHardenedList {
+			userData[key] = append(configList, hardenedList...)
+		}
+	}
+
+	mergedUserData, err := yaml.Marshal(userData)
+	if err != nil {
+		return "", err
+	}
+
+	return cloudConfigHead + "\n" + strings.TrimSpace(string(mergedUserData)), nil
+}
+
+// HardenedUserData is a function that will return the cloud-config that sets the kernel parameters and creates the etcd user
+// required by the CIS profile. The K3S audit policy is written to the path set in the kube-apiserver arguments.
+func HardenedUserData(terraformConfig *config.TerraformConfig) string {
+	isHarvester := strings.Contains(terraformConfig.Module, modules.Harvester)
+
+	userData := []string{cloudConfigHead}
+
+	if isHarvester {
+		userData = append(userData, qemuGuestAgent)
+	}
+
+	writeFiles := hardenedSysctls
+	if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		writeFiles += "\n" + k3sAuditPolicy
+	}
+
+	userData = append(userData, "write_files:\n"+writeFiles, "runcmd:", reloadSysctls)
+
+	switch {
+	case strings.Contains(terraformConfig.Module, clustertypes.RKE1):
+		userData = append(userData, rke1EtcdUser)
+	case strings.Contains(terraformConfig.Module, clustertypes.RKE2):
+		userData = append(userData, rke2EtcdUser)
+	}
+
+	if isHarvester {
+		userData = append(userData, startQemuAgent)
+	}
+
+	return strings.Join(userData, "\n")
+}
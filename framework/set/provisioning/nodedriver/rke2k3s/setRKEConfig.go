@@ -4,7 +4,10 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	harvester "github.com/rancher/tfp-automation/framework/set/provisioning/providers/harvester"
+	vsphere "github.com/rancher/tfp-automation/framework/set/provisioning/providers/vsphere"
 )
 
 // setRKEConfig is a function that will set the RKE configurations in the main.tf file.
@@ -12,19 +15,50 @@ func setRKEConfig(clusterBlockBody *hclwrite.Body, terraformConfig *config.Terra
 	rkeConfigBlock := clusterBlockBody.AppendNewBlock(defaults.RkeConfig, nil)
 	rkeConfigBlockBody := rkeConfigBlock.Body()
 
-	if terraformConfig.ChartValues != "" {
-		chartValues := hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\n" + terraformConfig.ChartValues + "\nEOF"},
+	chartValues := terraformConfig.ChartValues
+
+	switch terraformConfig.CloudProvider {
+	case cloudproviders.Harvester:
+		chartValues = appendChartValues(chartValues, harvester.GetHarvesterCloudProviderChartValues(terraformConfig))
+	case cloudproviders.RancherVsphere:
+		chartValues = appendChartValues(chartValues, vsphere.GetVsphereCloudProviderChartValues(terraformConfig))
+	}
+
+	if chartValues != "" {
+		chartValuesValue := hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "<<EOF\n" + chartValues + "\nEOF"},
 		})
 
-		rkeConfigBlockBody.SetAttributeRaw(defaults.ChartValues, chartValues)
+		rkeConfigBlockBody.SetAttributeRaw(defaults.ChartValues, chartValuesValue)
+	}
+
+	machineGlobalConfig := "cni: " + terraformConfig.CNI + "\ndisable-kube-proxy: " + terraformConfig.DisableKubeProxy
+
+	if terraformConfig.CloudProvider != "" {
+		machineGlobalConfig += "\n" + cloudProviderName + ": " + terraformConfig.CloudProvider
+	}
+
+	if secretsEncryptionConfig := SecretsEncryptionConfig(terraformConfig); secretsEncryptionConfig != "" {
+		machineGlobalConfig += "\n" + secretsEncryptionConfig
+	}
+
+	if hardenedConfig := HardenedConfig(terraformConfig); hardenedConfig != "" {
+		machineGlobalConfig += "\n" + hardenedConfig
 	}
 
 	machineGlobalConfigValue := hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\ncni: " + terraformConfig.CNI + "\ndisable-kube-proxy: " + terraformConfig.DisableKubeProxy + "\nEOF"},
+		hcl.TraverseRoot{Name: "<<EOF\n" + machineGlobalConfig + "\nEOF"},
 	})
 
 	rkeConfigBlockBody.SetAttributeRaw(defaults.MachineGlobalConfig, machineGlobalConfigValue)
 
 	return rkeConfigBlockBody, nil
 }
+
+func appendChartValues(chartValues, cloudProviderChartValues string) string {
+	if chartValues == "" {
+		return cloudProviderChartValues
+	}
+
+	return chartValues + "\n" + cloudProviderChartValues
+}
@@ -0,0 +1,32 @@
+package rke2k3s
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	RotateCertificates = "rotate_certificates"
+)
+
+// SetRotateCertificates is a function that will set the rotate_certificates block in the main.tf file for a RKE2/K3S cluster.
+// Certificates are rotated every time the generation is increased.
+func SetRotateCertificates(rkeConfigBlockBody *hclwrite.Body, terratestConfig *config.TerratestConfig) error {
+	rotateCertificatesBlock := rkeConfigBlockBody.AppendNewBlock(RotateCertificates, nil)
+	rotateCertificatesBlockBody := rotateCertificatesBlock.Body()
+
+	rotateCertificatesBlockBody.SetAttributeValue(Generation, cty.NumberIntVal(terratestConfig.CertRotationInput.Generation))
+
+	if len(terratestConfig.CertRotationInput.Services) > 0 {
+		var services []cty.Value
+		for _, service := range terratestConfig.CertRotationInput.Services {
+			services = append(services, cty.StringVal(service))
+		}
+
+		rotateCertificatesBlockBody.SetAttributeValue(defaults.Services, cty.ListVal(services))
+	}
+
+	return nil
+}
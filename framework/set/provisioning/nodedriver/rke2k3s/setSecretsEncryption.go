@@ -0,0 +1,42 @@
+package rke2k3s
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	RotateEncryptionKeys = "rotate_encryption_keys"
+
+	secretsEncryption = "secrets-encryption"
+)
+
+// SetRotateEncryptionKeys is a function that will set the rotate_encryption_keys block in the main.tf file for a RKE2/K3S cluster.
+// The secrets encryption keys are rotated every time the generation is increased.
+func SetRotateEncryptionKeys(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	rotateEncryptionKeysBlock := rkeConfigBlockBody.AppendNewBlock(RotateEncryptionKeys, nil)
+	rotateEncryptionKeysBlockBody := rotateEncryptionKeysBlock.Body()
+
+	rotateEncryptionKeysBlockBody.SetAttributeValue(Generation, cty.NumberIntVal(terraformConfig.SecretsEncryption.RotateEncryptionKeysGeneration))
+
+	return nil
+}
+
+// SecretsEncryptionConfig is a function that will return the machine global config that enables secrets encryption. RKE2 always
+// encrypts secrets, so the config is only returned for K3S clusters. Hardened K3S clusters always encrypt secrets.
+func SecretsEncryptionConfig(terraformConfig *config.TerraformConfig) string {
+	secretsEncryptionEnabled := terraformConfig.SecretsEncryption != nil && terraformConfig.SecretsEncryption.Enabled
+	if !secretsEncryptionEnabled && !terraformConfig.Hardened {
+		return ""
+	}
+
+	if !strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		return ""
+	}
+
+	return secretsEncryption + ": true"
+}
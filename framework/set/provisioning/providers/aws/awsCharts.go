@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	appV2     = "rancher2_app_v2"
+	catalogV2 = "rancher2_catalog_v2"
+
+	chartName     = "chart_name"
+	cleanupOnFail = "cleanup_on_fail"
+	repoName      = "repo_name"
+	url           = "url"
+	values        = "values"
+	wait          = "wait"
+)
+
+// setChart is a helper function that will set the rancher2_catalog_v2 of the chart repo and the rancher2_app_v2 of the chart
+// on the cluster with the given cluster ID expression in the main.tf file.
+func setChart(rootBody *hclwrite.Body, clusterID, resourcePrefix, name, namespace, repoURL, chartValues string) {
+	blockName := resourcePrefix + "-" + name
+
+	catalogBlock := rootBody.AppendNewBlock(defaults.Resource, []string{catalogV2, blockName})
+	catalogBlockBody := catalogBlock.Body()
+
+	catalogBlockBody.SetAttributeRaw(defaults.RancherClusterID, hclwrite.TokensForIdentifier(clusterID))
+	catalogBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(name))
+	catalogBlockBody.SetAttributeValue(url, cty.StringVal(repoURL))
+
+	rootBody.AppendNewline()
+
+	appBlock := rootBody.AppendNewBlock(defaults.Resource, []string{appV2, blockName})
+	appBlockBody := appBlock.Body()
+
+	appBlockBody.SetAttributeRaw(defaults.RancherClusterID, hclwrite.TokensForIdentifier(clusterID))
+	appBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(name))
+	appBlockBody.SetAttributeValue(defaults.Namespace, cty.StringVal(namespace))
+	appBlockBody.SetAttributeRaw(repoName, hclwrite.TokensForIdentifier(catalogV2+"."+blockName+"."+defaults.ResourceName))
+	appBlockBody.SetAttributeValue(chartName, cty.StringVal(name))
+
+	valuesValue := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + chartValues + "\nEOF"},
+	})
+
+	appBlockBody.SetAttributeRaw(values, valuesValue)
+	appBlockBody.SetAttributeValue(cleanupOnFail, cty.BoolVal(true))
+	appBlockBody.SetAttributeValue(wait, cty.BoolVal(true))
+
+	rootBody.AppendNewline()
+}
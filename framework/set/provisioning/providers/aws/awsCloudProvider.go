@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	clusterTagPrefix      = "kubernetes.io/cluster/"
+	clusterTagOwned       = "owned"
+	controlPlaneRoleLabel = "rke.cattle.io/control-plane-role"
+	etcdRoleLabel         = "rke.cattle.io/etcd-role"
+	workerRoleLabel       = "rke.cattle.io/worker-role"
+
+	controlPlaneExternalConfig = "disable-cloud-controller: true\nkube-apiserver-arg:\n  - cloud-provider=external\n" +
+		"kube-controller-manager-arg:\n  - cloud-provider=external\nkubelet-arg:\n  - cloud-provider=external"
+	nodeExternalConfig = "kubelet-arg:\n  - cloud-provider=external"
+
+	awsCloudControllerManifest = `apiVersion: helm.cattle.io/v1
+kind: HelmChart
+metadata:
+  name: aws-cloud-controller-manager
+  namespace: kube-system
+spec:
+  chart: aws-cloud-controller-manager
+  repo: https://kubernetes.github.io/cloud-provider-aws
+  targetNamespace: kube-system
+  bootstrap: true
+  valuesContent: |-
+    hostNetworking: true
+    nodeSelector:
+      node-role.kubernetes.io/control-plane: "true"
+    args:
+      - --configure-cloud-routes=false
+      - --cloud-provider=aws`
+
+	cloudControllerManagerName      = "aws-cloud-controller-manager"
+	cloudControllerManagerNamespace = "kube-system"
+	cloudControllerManagerRepoURL   = "https://kubernetes.github.io/cloud-provider-aws"
+
+	rke1CloudControllerManagerValues = `hostNetworking: true
+nodeSelector:
+  node-role.kubernetes.io/controlplane: "true"
+tolerations:
+  - effect: NoSchedule
+    key: node.cloudprovider.kubernetes.io/uninitialized
+    value: "true"
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/controlplane
+    value: "true"
+  - effect: NoExecute
+    key: node-role.kubernetes.io/etcd
+    value: "true"
+args:
+  - --configure-cloud-routes=false
+  - --cloud-provider=aws`
+)
+
+// SetAWSRKE2K3SCloudProvider is a helper function that will set the AWS out-of-tree cloud provider
+// configurations for RKE2/K3S clusters in the main.tf file.
+func SetAWSRKE2K3SCloudProvider(rkeConfigBlockBody *hclwrite.Body) {
+	setExternalMachineSelector(rkeConfigBlockBody, controlPlaneRoleLabel, controlPlaneExternalConfig)
+	setExternalMachineSelector(rkeConfigBlockBody, etcdRoleLabel, nodeExternalConfig)
+	setExternalMachineSelector(rkeConfigBlockBody, workerRoleLabel, nodeExternalConfig)
+
+	manifest := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + awsCloudControllerManifest + "\nEOF"},
+	})
+
+	rkeConfigBlockBody.SetAttributeRaw(defaults.AdditionalManifest, manifest)
+}
+
+// SetAWSRKE1CloudProvider is a helper function that will set the AWS out-of-tree cloud provider
+// configurations for RKE1 clusters in the main.tf file. RKE1 does not deploy the cloud controller manager
+// for external-aws, so its chart is installed on the cluster. The chart references the cluster rather than
+// its cluster sync, as the nodes keep the uninitialized taint until the cloud controller manager runs.
+func SetAWSRKE1CloudProvider(rootBody, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudProviderBlock := rkeConfigBlockBody.AppendNewBlock(defaults.CloudProvider, nil)
+	cloudProviderBlockBody := cloudProviderBlock.Body()
+
+	cloudProviderBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(cloudproviders.ExternalAWS))
+
+	rootBody.AppendNewline()
+
+	clusterID := defaults.Cluster + "." + terraformConfig.ResourcePrefix + ".id"
+	setChart(rootBody, clusterID, terraformConfig.ResourcePrefix, cloudControllerManagerName, cloudControllerManagerNamespace,
+		cloudControllerManagerRepoURL, rke1CloudControllerManagerValues)
+}
+
+// setCloudProviderMachineConfig is a helper function that will set the IAM instance profile and cluster
+// ownership tag required by the AWS cloud provider on the amazonec2_config block.
+func setCloudProviderMachineConfig(awsConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	if terraformConfig.AWSConfig.AWSIAMInstanceProfile != "" {
+		awsConfigBlockBody.SetAttributeValue(amazon.IAMProfile, cty.StringVal(terraformConfig.AWSConfig.AWSIAMInstanceProfile))
+	}
+
+	if terraformConfig.CloudProvider == cloudproviders.AWS {
+		clusterTag := clusterTagPrefix + terraformConfig.ResourcePrefix + "," + clusterTagOwned
+		awsConfigBlockBody.SetAttributeValue(amazon.Tags, cty.StringVal(clusterTag))
+	}
+}
+
+func setExternalMachineSelector(rkeConfigBlockBody *hclwrite.Body, roleLabel, selectorConfig string) {
+	machineSelectorBlock := rkeConfigBlockBody.AppendNewBlock(defaults.MachineSelectorConfig, nil)
+	machineSelectorBlockBody := machineSelectorBlock.Body()
+
+	configValue := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + selectorConfig + "\nEOF"},
+	})
+
+	machineSelectorBlockBody.SetAttributeRaw(defaults.Config, configValue)
+
+	labelSelectorBlock := machineSelectorBlockBody.AppendNewBlock(defaults.MachineLabelSelector, nil)
+	labelSelectorBlockBody := labelSelectorBlock.Body()
+
+	labelSelectorBlockBody.SetAttributeValue(defaults.MatchLabels, cty.MapVal(map[string]cty.Value{
+		roleLabel: cty.StringVal("true"),
+	}))
+}
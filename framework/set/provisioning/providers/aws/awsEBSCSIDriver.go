@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+)
+
+const (
+	ebsCSIDriverName      = "aws-ebs-csi-driver"
+	ebsCSIDriverNamespace = "kube-system"
+	ebsCSIDriverRepoURL   = "https://kubernetes-sigs.github.io/aws-ebs-csi-driver"
+
+	ebsCSIDriverValues = `storageClasses:
+  - name: ebs-sc
+    annotations:
+      storageclass.kubernetes.io/is-default-class: "true"
+    volumeBindingMode: WaitForFirstConsumer
+    parameters:
+      type: gp3`
+)
+
+// SetEBSCSIDriver is a helper function that will set the AWS EBS CSI driver chart in the main.tf file, which provisions the
+// volumes of the default storage class of clusters using the AWS cloud provider.
+func SetEBSCSIDriver(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	setChart(rootBody, rancher2.ClusterIDExpression(terraformConfig), terraformConfig.ResourcePrefix, ebsCSIDriverName,
+		ebsCSIDriverNamespace, ebsCSIDriverRepoURL, ebsCSIDriverValues)
+}
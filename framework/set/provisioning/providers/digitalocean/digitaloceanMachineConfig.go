@@ -0,0 +1,42 @@
+package digitalocean
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/digitalocean"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetDigitalOceanRKE2K3SMachineConfig is a helper function that will set the DigitalOcean RKE2/K3S
+// Terraform machine configurations in the main.tf file.
+func SetDigitalOceanRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	digitalOceanConfigBlock := machineConfigBlockBody.AppendNewBlock(digitalocean.DigitalOceanConfig, nil)
+	digitalOceanConfigBlockBody := digitalOceanConfigBlock.Body()
+
+	setDigitalOceanConfig(digitalOceanConfigBlockBody, terraformConfig)
+}
+
+// setDigitalOceanConfig is a helper function that will set the DigitalOcean droplet attributes shared
+// between the RKE1 node template and the RKE2/K3S machine config.
+func setDigitalOceanConfig(digitalOceanConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Image, cty.StringVal(terraformConfig.DigitalOceanConfig.Image))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Region, cty.StringVal(terraformConfig.DigitalOceanConfig.Region))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Size, cty.StringVal(terraformConfig.DigitalOceanConfig.Size))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.SSHUser, cty.StringVal(terraformConfig.DigitalOceanConfig.SSHUser))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Backups, cty.BoolVal(terraformConfig.DigitalOceanConfig.Backups))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.IPV6, cty.BoolVal(terraformConfig.DigitalOceanConfig.IPV6))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Monitoring, cty.BoolVal(terraformConfig.DigitalOceanConfig.Monitoring))
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.PrivateNetworking, cty.BoolVal(terraformConfig.DigitalOceanConfig.PrivateNetworking))
+
+	if terraformConfig.DigitalOceanConfig.SSHPort != "" {
+		digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.SSHPort, cty.StringVal(terraformConfig.DigitalOceanConfig.SSHPort))
+	}
+
+	if terraformConfig.DigitalOceanConfig.Tags != "" {
+		digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Tags, cty.StringVal(terraformConfig.DigitalOceanConfig.Tags))
+	}
+
+	if terraformConfig.DigitalOceanConfig.Userdata != "" {
+		digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.Userdata, cty.StringVal(terraformConfig.DigitalOceanConfig.Userdata))
+	}
+}
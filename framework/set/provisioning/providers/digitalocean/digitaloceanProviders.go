@@ -0,0 +1,34 @@
+package digitalocean
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/digitalocean"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetDigitalOceanRKE1Provider is a helper function that will set the DigitalOcean RKE1
+// Terraform configurations in the main.tf file.
+func SetDigitalOceanRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	digitalOceanConfigBlock := nodeTemplateBlockBody.AppendNewBlock(digitalocean.DigitalOceanConfig, nil)
+	digitalOceanConfigBlockBody := digitalOceanConfigBlock.Body()
+
+	digitalOceanConfigBlockBody.SetAttributeValue(digitalocean.AccessToken, cty.StringVal(terraformConfig.DigitalOceanCredentials.AccessToken))
+
+	setDigitalOceanConfig(digitalOceanConfigBlockBody, terraformConfig)
+}
+
+// SetDigitalOceanRKE2K3SProvider is a helper function that will set the DigitalOcean RKE2/K3S
+// Terraform provider details in the main.tf file.
+func SetDigitalOceanRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	digitalOceanCredBlock := cloudCredBlockBody.AppendNewBlock(digitalocean.DigitalOceanCredentialConfig, nil)
+	digitalOceanCredBlockBody := digitalOceanCredBlock.Body()
+
+	digitalOceanCredBlockBody.SetAttributeValue(digitalocean.AccessToken, cty.StringVal(terraformConfig.DigitalOceanCredentials.AccessToken))
+}
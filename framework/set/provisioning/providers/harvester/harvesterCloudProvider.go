@@ -0,0 +1,70 @@
+package harvester
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	cloudProviderConfig     = "cloud-provider-config"
+	cloudProviderConfigPath = "/var/lib/rancher/rke2/etc/config-files/cloud-provider-config"
+	cloudProviderSuffix     = "-cloud-provider"
+	credential              = "credential"
+	localCluster            = "local"
+	namespace               = "fleet-default"
+
+	authorizedForCluster      = "v2prov-secret-authorized-for-cluster"
+	deletesOnClusterRemoval   = "v2prov-authorized-secret-deletes-on-cluster-removal"
+	harvesterCloudProviderKey = "harvester-cloud-provider"
+)
+
+// GetHarvesterCloudProviderChartValues is a helper function that will return the harvester-cloud-provider
+// chart values for RKE2 clusters.
+func GetHarvesterCloudProviderChartValues(terraformConfig *config.TerraformConfig) string {
+	return harvesterCloudProviderKey + ":\n" +
+		"  cloudConfigPath: " + cloudProviderConfigPath + "\n" +
+		"  global:\n" +
+		"    cattle:\n" +
+		"      clusterName: " + terraformConfig.ResourcePrefix
+}
+
+// SetHarvesterRKE2CloudProvider is a helper function that will set the Harvester cloud provider secret and
+// machine selector configurations for RKE2 clusters in the main.tf file.
+func SetHarvesterRKE2CloudProvider(rootBody, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	secretName := terraformConfig.ResourcePrefix + cloudProviderSuffix
+
+	secretBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.SecretV2, secretName})
+	secretBlockBody := secretBlock.Body()
+
+	provider := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.Rancher2 + "." + defaults.AdminUser)},
+	}
+
+	secretBlockBody.SetAttributeRaw(defaults.Provider, provider)
+	secretBlockBody.SetAttributeValue(defaults.RancherClusterID, cty.StringVal(localCluster))
+	secretBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(secretName))
+	secretBlockBody.SetAttributeValue(defaults.Namespace, cty.StringVal(namespace))
+
+	secretBlockBody.SetAttributeValue(defaults.Annotations, cty.MapVal(map[string]cty.Value{
+		authorizedForCluster:    cty.StringVal(terraformConfig.ResourcePrefix),
+		deletesOnClusterRemoval: cty.StringVal("true"),
+	}))
+
+	secretBlockBody.SetAttributeValue(defaults.Data, cty.MapVal(map[string]cty.Value{
+		credential: cty.StringVal(terraformConfig.HarvesterConfig.CloudProviderConfig),
+	}))
+
+	machineSelectorBlock := rkeConfigBlockBody.AppendNewBlock(defaults.MachineSelectorConfig, nil)
+	machineSelectorBlockBody := machineSelectorBlock.Body()
+
+	configValue := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + cloudProviderConfig + ": secret://" + namespace + ":${" + defaults.SecretV2 + "." +
+			secretName + ".name}\nEOF"},
+	})
+
+	machineSelectorBlockBody.SetAttributeRaw(defaults.Config, configValue)
+}
@@ -0,0 +1,76 @@
+package vsphere
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	vsphereCloudProvider = "vsphere_cloud_provider"
+	global               = "global"
+	insecureFlag         = "insecure_flag"
+	virtualCenter        = "virtual_center"
+	datacenters          = "datacenters"
+	workspace            = "workspace"
+	server               = "server"
+	datacenter           = "datacenter"
+	defaultDatastore     = "default_datastore"
+)
+
+// GetVsphereCloudProviderChartValues is a helper function that will return the rancher-vsphere-cpi
+// and rancher-vsphere-csi chart values for RKE2/K3S clusters.
+func GetVsphereCloudProviderChartValues(terraformConfig *config.TerraformConfig) string {
+	vCenter := "  vCenter:\n" +
+		"    host: " + terraformConfig.VsphereCredentials.Vcenter + "\n"
+
+	if terraformConfig.VsphereCredentials.VcenterPort != "" {
+		vCenter += "    port: " + terraformConfig.VsphereCredentials.VcenterPort + "\n"
+	}
+
+	vCenter += "    datacenters: " + terraformConfig.VsphereConfig.DataCenter + "\n" +
+		"    username: " + terraformConfig.VsphereCredentials.Username + "\n" +
+		"    password: " + terraformConfig.VsphereCredentials.Password + "\n"
+
+	chartValues := "rancher-vsphere-cpi:\n" + vCenter
+	chartValues += "rancher-vsphere-csi:\n" + vCenter + "    clusterId: " + terraformConfig.ResourcePrefix + "\n"
+	chartValues += "  storageClass:\n    datastoreURL: " + terraformConfig.VsphereConfig.DatastoreURL
+
+	return chartValues
+}
+
+// SetVsphereRKE1CloudProvider is a helper function that will set the Vsphere in-tree cloud provider
+// configurations for RKE1 clusters in the main.tf file.
+func SetVsphereRKE1CloudProvider(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudProviderBlock := rkeConfigBlockBody.AppendNewBlock(defaults.CloudProvider, nil)
+	cloudProviderBlockBody := cloudProviderBlock.Body()
+
+	cloudProviderBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(cloudproviders.Vsphere))
+
+	vsphereCloudProviderBlock := cloudProviderBlockBody.AppendNewBlock(vsphereCloudProvider, nil)
+	vsphereCloudProviderBlockBody := vsphereCloudProviderBlock.Body()
+
+	globalBlock := vsphereCloudProviderBlockBody.AppendNewBlock(global, nil)
+	globalBlockBody := globalBlock.Body()
+
+	globalBlockBody.SetAttributeValue(insecureFlag, cty.BoolVal(true))
+
+	virtualCenterBlock := vsphereCloudProviderBlockBody.AppendNewBlock(virtualCenter, nil)
+	virtualCenterBlockBody := virtualCenterBlock.Body()
+
+	virtualCenterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.VsphereCredentials.Vcenter))
+	virtualCenterBlockBody.SetAttributeValue(datacenters, cty.StringVal(terraformConfig.VsphereConfig.DataCenter))
+	virtualCenterBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereCredentials.Username))
+	virtualCenterBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.VsphereCredentials.Password))
+	virtualCenterBlockBody.SetAttributeValue(defaults.Port, cty.StringVal(terraformConfig.VsphereCredentials.VcenterPort))
+
+	workspaceBlock := vsphereCloudProviderBlockBody.AppendNewBlock(workspace, nil)
+	workspaceBlockBody := workspaceBlock.Body()
+
+	workspaceBlockBody.SetAttributeValue(server, cty.StringVal(terraformConfig.VsphereCredentials.Vcenter))
+	workspaceBlockBody.SetAttributeValue(datacenter, cty.StringVal(terraformConfig.VsphereConfig.DataCenter))
+	workspaceBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(terraformConfig.VsphereConfig.Folder))
+	workspaceBlockBody.SetAttributeValue(defaultDatastore, cty.StringVal(terraformConfig.VsphereConfig.DataStore))
+}
@@ -38,7 +38,7 @@ func addClusterRole(client *rancher.Client, newFile *hclwrite.File, rootBody *hc
 		clusterRoleTemplateBindingBlockBody.SetAttributeValue(clusterID, cty.StringVal(clusterBlockID))
 	}
 
-	clusterRoleTemplateBindingBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterRoleTemplateBindingName))
+	clusterRoleTemplateBindingBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(ClusterRoleTemplateBindingName))
 	clusterRoleTemplateBindingBlockBody.SetAttributeValue(roleTemplateID, cty.StringVal(string(rbacRole)))
 
 	newUser := hclwrite.Tokens{
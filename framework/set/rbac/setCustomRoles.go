@@ -0,0 +1,283 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	globalRole   = "rancher2_global_role"
+	roleTemplate = "rancher2_role_template"
+
+	apiGroups             = "api_groups"
+	context               = "context"
+	inheritedClusterRoles = "inherited_cluster_roles"
+	newUserDefault        = "new_user_default"
+	nonResourceURLs       = "non_resource_urls"
+	resourceNames         = "resource_names"
+	resources             = "resources"
+	roleTemplateIDs       = "role_template_ids"
+	rules                 = "rules"
+	verbs                 = "verbs"
+
+	clusterContext         = "cluster"
+	projectContext         = "project"
+	customRolesProject     = "custom-roles"
+	customRolesProjectName = "tfp-custom-roles-project"
+	bindingSuffix          = "-binding"
+)
+
+// addCustomRoles is a helper function that will add the custom role templates and global roles of the config, named with the
+// resource prefix and each bound to a new `user` member, in the main.tf file. Project role templates are bound in a project created for the custom roles.
+func addCustomRoles(client *rancher.Client, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	rbacRole config.Role, isRKE1 bool) (*hclwrite.File, *hclwrite.Body, error) {
+	if len(terraformConfig.RoleTemplates) == 0 && len(terraformConfig.GlobalRoles) == 0 {
+		return newFile, rootBody, fmt.Errorf("Role templates or global roles must be set for RBAC role: %v", rbacRole)
+	}
+
+	projectCreated := false
+
+	for _, customRoleTemplate := range terraformConfig.RoleTemplates {
+		if customRoleTemplate.Name == "" {
+			return newFile, rootBody, fmt.Errorf("Name must be set for every role template of cluster: %v", terraformConfig.ResourcePrefix)
+		}
+
+		if customRoleTemplate.Context != clusterContext && customRoleTemplate.Context != projectContext {
+			return newFile, rootBody, fmt.Errorf("Unsupported context for role template %s: %v", customRoleTemplate.Name, customRoleTemplate.Context)
+		}
+
+		blockName := CustomRoleName(terraformConfig, customRoleTemplate.Name)
+
+		roleTemplateBlock := rootBody.AppendNewBlock(defaults.Resource, []string{roleTemplate, blockName})
+		roleTemplateBlockBody := roleTemplateBlock.Body()
+
+		roleTemplateBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(blockName))
+		roleTemplateBlockBody.SetAttributeValue(context, cty.StringVal(customRoleTemplate.Context))
+
+		if len(customRoleTemplate.RoleTemplateIDs) > 0 {
+			roleTemplateBlockBody.SetAttributeValue(roleTemplateIDs, stringList(customRoleTemplate.RoleTemplateIDs))
+		}
+
+		setRules(roleTemplateBlockBody, customRoleTemplate.Rules)
+
+		rootBody.AppendNewline()
+
+		user, err := setUsers(newFile, rootBody, rbacRole)
+		if err != nil {
+			return newFile, rootBody, err
+		}
+
+		rootBody.AppendNewline()
+
+		var bindingBlockBody *hclwrite.Body
+
+		if customRoleTemplate.Context == clusterContext {
+			bindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{clusterRoleTemplateBinding, blockName})
+			bindingBlockBody = bindingBlock.Body()
+
+			err = setClusterID(client, bindingBlockBody, terraformConfig, isRKE1)
+			if err != nil {
+				return newFile, rootBody, err
+			}
+		} else {
+			if !projectCreated {
+				err = setCustomRolesProject(client, rootBody, terraformConfig, isRKE1)
+				if err != nil {
+					return newFile, rootBody, err
+				}
+
+				projectCreated = true
+			}
+
+			bindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{projectRoleTemplateBinding, blockName})
+			bindingBlockBody = bindingBlock.Body()
+
+			projectBlockID := hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(project + "." + terraformConfig.ResourcePrefix + "-" + customRolesProject + ".id")},
+			}
+
+			bindingBlockBody.SetAttributeRaw(projectID, projectBlockID)
+		}
+
+		bindingBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(blockName+bindingSuffix))
+
+		roleTemplateBlockID := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(roleTemplate + "." + blockName + ".id")},
+		}
+
+		bindingBlockBody.SetAttributeRaw(roleTemplateID, roleTemplateBlockID)
+
+		newUser := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(rancherUser + "." + user + ".id")},
+		}
+
+		bindingBlockBody.SetAttributeRaw(userID, newUser)
+
+		if customRoleTemplate.Context == clusterContext {
+			setClusterDependsOn(bindingBlockBody, terraformConfig, isRKE1)
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	for _, customGlobalRole := range terraformConfig.GlobalRoles {
+		if customGlobalRole.Name == "" {
+			return newFile, rootBody, fmt.Errorf("Name must be set for every global role of cluster: %v", terraformConfig.ResourcePrefix)
+		}
+
+		blockName := CustomRoleName(terraformConfig, customGlobalRole.Name)
+
+		globalRoleBlock := rootBody.AppendNewBlock(defaults.Resource, []string{globalRole, blockName})
+		globalRoleBlockBody := globalRoleBlock.Body()
+
+		globalRoleBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(blockName))
+		globalRoleBlockBody.SetAttributeValue(newUserDefault, cty.BoolVal(false))
+
+		if len(customGlobalRole.InheritedClusterRoles) > 0 {
+			globalRoleBlockBody.SetAttributeValue(inheritedClusterRoles, stringList(customGlobalRole.InheritedClusterRoles))
+		}
+
+		setRules(globalRoleBlockBody, customGlobalRole.Rules)
+
+		rootBody.AppendNewline()
+
+		user, err := setUsers(newFile, rootBody, rbacRole)
+		if err != nil {
+			return newFile, rootBody, err
+		}
+
+		rootBody.AppendNewline()
+
+		globalRoleBindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{globalRoleBinding, blockName})
+		globalRoleBindingBlockBody := globalRoleBindingBlock.Body()
+
+		globalRoleBindingBlockBody.SetAttributeValue(name, cty.StringVal(blockName+bindingSuffix))
+
+		globalRoleBlockID := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(globalRole + "." + blockName + ".id")},
+		}
+
+		globalRoleBindingBlockBody.SetAttributeRaw(globalRoleID, globalRoleBlockID)
+
+		newUser := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(rancherUser + "." + user + ".id")},
+		}
+
+		globalRoleBindingBlockBody.SetAttributeRaw(userID, newUser)
+
+		rootBody.AppendNewline()
+	}
+
+	return newFile, rootBody, nil
+}
+
+// CustomRoleName is a function that will return the name of a custom role template or global role of the config in Rancher, which
+// is prefixed with the resource prefix so that the roles of different clusters do not collide.
+func CustomRoleName(terraformConfig *config.TerraformConfig, roleName string) string {
+	return terraformConfig.ResourcePrefix + "-" + roleName
+}
+
+// setCustomRolesProject is a helper function that will set the project of the custom project role templates in the main.tf file.
+func setCustomRolesProject(client *rancher.Client, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, isRKE1 bool) error {
+	projectBlock := rootBody.AppendNewBlock(defaults.Resource, []string{project, terraformConfig.ResourcePrefix + "-" + customRolesProject})
+	projectBlockBody := projectBlock.Body()
+
+	projectBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(customRolesProjectName))
+
+	err := setClusterID(client, projectBlockBody, terraformConfig, isRKE1)
+	if err != nil {
+		return err
+	}
+
+	setClusterDependsOn(projectBlockBody, terraformConfig, isRKE1)
+
+	rootBody.AppendNewline()
+
+	return nil
+}
+
+// setClusterID is a helper function that will set the cluster ID of the cluster on the given block in the main.tf file.
+func setClusterID(client *rancher.Client, blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, isRKE1 bool) error {
+	if isRKE1 {
+		clusterBlockID := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.Cluster + "." + terraformConfig.ResourcePrefix + ".id")},
+		}
+
+		blockBody.SetAttributeRaw(clusterID, clusterBlockID)
+
+		return nil
+	}
+
+	clusterBlockID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	if err != nil {
+		return err
+	}
+
+	blockBody.SetAttributeValue(clusterID, cty.StringVal(clusterBlockID))
+
+	return nil
+}
+
+// setClusterDependsOn is a helper function that will set the dependency of the given block on the cluster in the main.tf file.
+func setClusterDependsOn(blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, isRKE1 bool) {
+	var dependsOn string
+	if isRKE1 {
+		dependsOn = `[` + defaults.Cluster + `.` + terraformConfig.ResourcePrefix + `]`
+	} else {
+		dependsOn = `[` + defaults.ClusterV2 + `.` + terraformConfig.ResourcePrefix + `]`
+	}
+
+	value := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOn)},
+	}
+
+	blockBody.SetAttributeRaw(defaults.DependsOn, value)
+}
+
+// setRules is a helper function that will set the policy rules of a role template or global role in the main.tf file.
+func setRules(blockBody *hclwrite.Body, policyRules []management.PolicyRule) {
+	for _, policyRule := range policyRules {
+		rulesBlock := blockBody.AppendNewBlock(rules, nil)
+		rulesBlockBody := rulesBlock.Body()
+
+		if len(policyRule.APIGroups) > 0 {
+			rulesBlockBody.SetAttributeValue(apiGroups, stringList(policyRule.APIGroups))
+		}
+
+		if len(policyRule.NonResourceURLs) > 0 {
+			rulesBlockBody.SetAttributeValue(nonResourceURLs, stringList(policyRule.NonResourceURLs))
+		}
+
+		if len(policyRule.ResourceNames) > 0 {
+			rulesBlockBody.SetAttributeValue(resourceNames, stringList(policyRule.ResourceNames))
+		}
+
+		if len(policyRule.Resources) > 0 {
+			rulesBlockBody.SetAttributeValue(resources, stringList(policyRule.Resources))
+		}
+
+		rulesBlockBody.SetAttributeValue(verbs, stringList(policyRule.Verbs))
+	}
+}
+
+// stringList is a helper function that will return the given strings as a list value.
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	listValues := make([]cty.Value, 0, len(values))
+	for _, value := range values {
+		listValues = append(listValues, cty.StringVal(value))
+	}
+
+	return cty.ListVal(listValues)
+}
@@ -16,15 +16,15 @@ const (
 	clusterRoleTemplateBinding = "rancher2_cluster_role_template_binding"
 	projectRoleTemplateBinding = "rancher2_project_role_template_binding"
 
-	clusterRoleTemplateBindingName = "tfp-cluster-role-template-binding"
+	ClusterRoleTemplateBindingName = "tfp-cluster-role-template-binding"
 	projectName                    = "tfp-project"
-	projectRoleTemplateBindingName = "tfp-project-role-template-binding"
+	ProjectRoleTemplateBindingName = "tfp-project-role-template-binding"
 	clusterID                      = "cluster_id"
 	projectID                      = "project_id"
 	roleTemplateID                 = "role_template_id"
 )
 
-// RoleCheck is a helper function that will check if the RBAC role is either `clusterOwner` or `projectOwner`.
+// RoleCheck is a helper function that will check if the RBAC role is either `clusterOwner`, `projectOwner` or `customRoles`.
 func RoleCheck(client *rancher.Client, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, terraform *config.TerraformConfig,
 	rbacRole config.Role, isRKE1 bool) (*hclwrite.File, *hclwrite.Body, error) {
 	if strings.Contains(string(rbacRole), string(config.ClusterOwner)) {
@@ -37,6 +37,11 @@ func RoleCheck(client *rancher.Client, newFile *hclwrite.File, rootBody *hclwrit
 		if err != nil {
 			return newFile, rootBody, err
 		}
+	} else if strings.Contains(string(rbacRole), string(config.CustomRoles)) {
+		newFile, rootBody, err := addCustomRoles(client, newFile, rootBody, terraform, rbacRole, isRKE1)
+		if err != nil {
+			return newFile, rootBody, err
+		}
 	}
 
 	return newFile, rootBody, nil
@@ -58,7 +58,7 @@ func addProjectMember(client *rancher.Client, newFile *hclwrite.File, rootBody *
 	projectRoleTemplateBindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{projectRoleTemplateBinding, terraformConfig.ResourcePrefix})
 	projectRoleTemplateBindingBody := projectRoleTemplateBindingBlock.Body()
 
-	projectRoleTemplateBindingBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(projectRoleTemplateBindingName))
+	projectRoleTemplateBindingBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(ProjectRoleTemplateBindingName))
 
 	projectBlockID := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(project + "." + terraformConfig.ResourcePrefix + ".id")},
@@ -0,0 +1,88 @@
+package chartrepo
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	installChartRepo = "install_chart_repo"
+
+	defaultImage = "nginx:stable"
+	defaultPort  = "8443"
+)
+
+// CreateChartRepo is a function that will set the chart repository configurations in the main.tf file.
+func CreateChartRepo(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, chartRepoPublicIP string, certificates *certificates) (*os.File, error) {
+	userDir, _ := rancher2.SetKeyPath(keypath.ChartRepoKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+
+	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/chartrepo/setup.sh")
+
+	scriptContent, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, chartRepoPublicIP, installChartRepo)
+
+	args := []string{
+		terraformConfig.Standalone.OSUser,
+		terraformConfig.StandaloneChartRepo.Username,
+		terraformConfig.StandaloneChartRepo.Password,
+		Port(terraformConfig),
+		chartRepoPublicIP,
+		Image(terraformConfig),
+		base64.StdEncoding.EncodeToString([]byte(certificates.serverCert)),
+		base64.StdEncoding.EncodeToString([]byte(certificates.serverKey)),
+		ChartName,
+		ChartVersion,
+		GitBranch,
+	}
+
+	command := "bash -c '/tmp/setup.sh " + strings.Join(args, " ") + " || true'"
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo '" + string(scriptContent) + "' > /tmp/setup.sh"),
+		cty.StringVal("chmod +x /tmp/setup.sh"),
+		cty.StringVal(command),
+	}))
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Image is a function that will return the nginx image serving the chart repository, falling back to the upstream image when one
+// is not set.
+func Image(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneChartRepo.Image != "" {
+		return terraformConfig.StandaloneChartRepo.Image
+	}
+
+	return defaultImage
+}
+
+// Port is a function that will return the port the chart repository is exposed on, falling back to 8443 when one is not set.
+func Port(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneChartRepo.Port != "" {
+		return terraformConfig.StandaloneChartRepo.Port
+	}
+
+	return defaultPort
+}
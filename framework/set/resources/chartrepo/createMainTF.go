@@ -0,0 +1,75 @@
+package chartrepo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	chartRepo         = "chartrepo"
+	chartRepoPublicIP = "chartrepo_public_ip"
+
+	terraformConst = "terraform"
+)
+
+// CreateMainTF is a helper function that will create the main.tf file for creating a standalone chart repository. The server
+// serves a Helm index and a git repo of the same chart over HTTPS with basic auth, signed by the returned CA.
+func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (*Server, error) {
+	var file *os.File
+	file = sanity.OpenFile(file, keyPath)
+	defer file.Close()
+
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
+	tfBlockBody := tfBlock.Body()
+
+	instances := []string{chartRepo}
+
+	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
+	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating resources. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	chartRepoPublicIP := terraform.Output(t, terraformOptions, chartRepoPublicIP)
+
+	certificates, err := generateCertificates(chartRepoPublicIP)
+	if err != nil {
+		return nil, err
+	}
+
+	file = sanity.OpenFile(file, keyPath)
+	logrus.Infof("Creating chart repository...")
+	file, err = CreateChartRepo(file, newFile, rootBody, terraformConfig, terratestConfig, chartRepoPublicIP, certificates)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating chart repository. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	return newServer(terraformConfig, chartRepoPublicIP, certificates.caCert), nil
+}
@@ -0,0 +1,56 @@
+package chartrepo
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rancher/shepherd/pkg/nodes"
+	"github.com/rancher/tfp-automation/config"
+)
+
+const (
+	ChartName    = "tfp-chart"
+	ChartVersion = "0.1.0"
+	GitBranch    = "main"
+
+	rotateCredentials = "printf '%%s:%%s\\n' '%s' \"$(openssl passwd -apr1 '%s')\" | sudo tee /home/%s/chartrepo/htpasswd > /dev/null"
+)
+
+// Server holds the endpoints of the standalone chart repository and the PEM encoded CA its certificate is signed by.
+type Server struct {
+	Host     string
+	HelmURL  string
+	GitURL   string
+	CABundle string
+}
+
+// newServer is a helper function that will return the endpoints of the chart repository served on the given host.
+func newServer(terraformConfig *config.TerraformConfig, host, caBundle string) *Server {
+	baseURL := fmt.Sprintf("https://%s:%s", host, Port(terraformConfig))
+
+	return &Server{
+		Host:     host,
+		HelmURL:  baseURL + "/charts",
+		GitURL:   baseURL + "/git/charts.git",
+		CABundle: caBundle,
+	}
+}
+
+// RotateCredentials is a function that will replace the basic auth credentials of the chart repository over SSH. nginx reads the
+// htpasswd file on every request, so the new credentials are enforced without restarting the server.
+func RotateCredentials(terraformConfig *config.TerraformConfig, host, username, password string) error {
+	sshKey, err := os.ReadFile(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	node := &nodes.Node{
+		PublicIPAddress: host,
+		SSHUser:         terraformConfig.Standalone.OSUser,
+		SSHKey:          sshKey,
+	}
+
+	_, err = node.ExecuteCommand(fmt.Sprintf(rotateCredentials, username, password, terraformConfig.Standalone.OSUser))
+
+	return err
+}
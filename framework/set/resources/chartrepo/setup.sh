@@ -0,0 +1,114 @@
+#!/bin/bash
+
+USER=$1
+USERNAME=$2
+PASSWORD=$3
+PORT=$4
+HOST=$5
+IMAGE=$6
+CERT=$7
+KEY=$8
+CHART_NAME=$9
+CHART_VERSION=${10}
+GIT_BRANCH=${11}
+CHART_REPO_DIR="/home/${USER}/chartrepo"
+BUILD_DIR="${CHART_REPO_DIR}/build"
+
+set -e
+
+if ! command -v docker &> /dev/null; then
+    echo "Installing Docker..."
+    curl -fsSL https://get.docker.com | sudo sh
+fi
+
+sudo mkdir -p ${CHART_REPO_DIR}/certs ${CHART_REPO_DIR}/charts ${CHART_REPO_DIR}/git ${BUILD_DIR}/repo/charts/${CHART_NAME}/templates
+
+echo "Writing certificate for ${HOST}..."
+echo ${CERT} | base64 -d | sudo tee ${CHART_REPO_DIR}/certs/tls.crt > /dev/null
+echo ${KEY} | base64 -d | sudo tee ${CHART_REPO_DIR}/certs/tls.key > /dev/null
+
+echo "Building chart ${CHART_NAME} ${CHART_VERSION}..."
+sudo tee ${BUILD_DIR}/repo/charts/${CHART_NAME}/Chart.yaml > /dev/null <<EOT
+apiVersion: v2
+name: ${CHART_NAME}
+version: ${CHART_VERSION}
+appVersion: "${CHART_VERSION}"
+description: Chart served by the tfp-automation standalone chart repository
+EOT
+
+sudo tee ${BUILD_DIR}/repo/charts/${CHART_NAME}/templates/configmap.yaml > /dev/null <<EOT
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  chart: {{ .Chart.Name }}
+  version: {{ .Chart.Version }}
+EOT
+
+sudo tar -czf ${CHART_REPO_DIR}/charts/${CHART_NAME}-${CHART_VERSION}.tgz -C ${BUILD_DIR}/repo/charts ${CHART_NAME}
+DIGEST=$(sha256sum ${CHART_REPO_DIR}/charts/${CHART_NAME}-${CHART_VERSION}.tgz | cut -d " " -f 1)
+CREATED=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+
+sudo tee ${CHART_REPO_DIR}/charts/index.yaml > /dev/null <<EOT
+apiVersion: v1
+entries:
+  ${CHART_NAME}:
+    - apiVersion: v2
+      name: ${CHART_NAME}
+      version: ${CHART_VERSION}
+      appVersion: "${CHART_VERSION}"
+      description: Chart served by the tfp-automation standalone chart repository
+      created: "${CREATED}"
+      digest: ${DIGEST}
+      urls:
+        - https://${HOST}:${PORT}/charts/${CHART_NAME}-${CHART_VERSION}.tgz
+generated: "${CREATED}"
+EOT
+
+echo "Creating git repository..."
+GIT="sudo docker run --rm -v ${CHART_REPO_DIR}:/work -w /work alpine/git"
+${GIT} -C build/repo init -b ${GIT_BRANCH}
+${GIT} -C build/repo add -A
+${GIT} -C build/repo -c user.name=tfp-automation -c user.email=tfp-automation@rancher.com commit -m "Add ${CHART_NAME} ${CHART_VERSION}"
+${GIT} clone --bare build/repo git/charts.git
+${GIT} -C git/charts.git update-server-info
+
+echo "Writing credentials..."
+printf "%s:%s\n" ${USERNAME} $(openssl passwd -apr1 ${PASSWORD}) | sudo tee ${CHART_REPO_DIR}/htpasswd > /dev/null
+
+sudo tee ${CHART_REPO_DIR}/default.conf > /dev/null <<EOT
+server {
+    listen 443 ssl;
+    server_name ${HOST};
+
+    ssl_certificate /etc/nginx/certs/tls.crt;
+    ssl_certificate_key /etc/nginx/certs/tls.key;
+
+    auth_basic "Chart Repository";
+    auth_basic_user_file /etc/nginx/htpasswd;
+
+    root /usr/share/nginx/html;
+}
+EOT
+
+echo "Starting chart repository..."
+sudo docker run -d --name chartrepo --restart always \
+    -p ${PORT}:443 \
+    -v ${CHART_REPO_DIR}/certs:/etc/nginx/certs:ro \
+    -v ${CHART_REPO_DIR}/htpasswd:/etc/nginx/htpasswd:ro \
+    -v ${CHART_REPO_DIR}/default.conf:/etc/nginx/conf.d/default.conf:ro \
+    -v ${CHART_REPO_DIR}/charts:/usr/share/nginx/html/charts:ro \
+    -v ${CHART_REPO_DIR}/git:/usr/share/nginx/html/git:ro \
+    ${IMAGE}
+
+echo "Waiting for the chart repository to be ready..."
+for i in $(seq 1 30); do
+    if curl -sfk -u ${USERNAME}:${PASSWORD} https://localhost:${PORT}/charts/index.yaml > /dev/null; then
+        break
+    fi
+
+    sleep 5
+done
+
+echo "Chart repository is available at https://${HOST}:${PORT}/charts and https://${HOST}:${PORT}/git/charts.git"
@@ -27,18 +27,20 @@ func AddWindowsNodeToImportedCluster(rootBody *hclwrite.Body, terraformConfig *c
 		return err
 	}
 
-	addImportedWindowsNode(rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, serverOneScriptContent)
-
-	return nil
+	return addImportedWindowsNode(rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, serverOneScriptContent)
 }
 
 // addImportedWindowsNode is a helper function that will add an additional Windows node to the initial server.
 func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	serverOnePrivateIP, windowsNodePublicDNS,
-	token string, script []byte) {
+	token string, script []byte) error {
 	copyScriptName := terraformConfig.ResourcePrefix + copyScript + windowsServer
 
-	nullResourceBlockBody, provisionerBlockBody := nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, copyScriptName)
+	nullResourceBlockBody, provisionerBlockBody, err := nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, copyScriptName)
+	if err != nil {
+		return err
+	}
+
 	rootBody.AppendNewline()
 
 	dependsOnServer := `[` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows` + `]`
@@ -71,7 +73,10 @@ func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	}
 
 	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal(inlineCommands))
-	nullResourceBlockBody, provisionerBlockBody = nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, addWindowsNode)
+	nullResourceBlockBody, provisionerBlockBody, err = nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, addWindowsNode)
+	if err != nil {
+		return err
+	}
 
 	version := terraformConfig.Standalone.RKE2Version
 	version += "+rke2r1"
@@ -92,4 +97,6 @@ func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	}
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
+
+	return nil
 }
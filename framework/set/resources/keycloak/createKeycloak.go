@@ -0,0 +1,90 @@
+package keycloak
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	installKeycloak = "install_keycloak"
+
+	defaultImage = "quay.io/keycloak/keycloak:26.0"
+	defaultPort  = "8080"
+)
+
+// CreateKeycloak is a function that will set the Keycloak configurations in the main.tf file. The realm is imported on startup
+// with a test user in a group, an OIDC client for the keycloakoidc and genericoidc providers and a SAML client for Rancher.
+func CreateKeycloak(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, keycloakPublicIP, rancherHost, clientSecret string) (*os.File, error) {
+	userDir, _ := rancher2.SetKeyPath(keypath.KeycloakKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+
+	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/keycloak/setup.sh")
+
+	scriptContent, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, keycloakPublicIP, installKeycloak)
+
+	args := []string{
+		terraformConfig.Standalone.OSUser,
+		terraformConfig.StandaloneKeycloak.AdminUsername,
+		terraformConfig.StandaloneKeycloak.AdminPassword,
+		Port(terraformConfig),
+		keycloakPublicIP,
+		Image(terraformConfig),
+		Realm,
+		terraformConfig.StandaloneKeycloak.Username,
+		terraformConfig.StandaloneKeycloak.Password,
+		Group,
+		OIDCClientID,
+		clientSecret,
+		SAMLEntityID(rancherHost),
+		samlACSURL(rancherHost),
+	}
+
+	command := "bash -c '/tmp/setup.sh " + strings.Join(args, " ") + " || true'"
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo '" + string(scriptContent) + "' > /tmp/setup.sh"),
+		cty.StringVal("chmod +x /tmp/setup.sh"),
+		cty.StringVal(command),
+	}))
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Image is a function that will return the Keycloak image, falling back to the upstream image when one is not set.
+func Image(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneKeycloak.Image != "" {
+		return terraformConfig.StandaloneKeycloak.Image
+	}
+
+	return defaultImage
+}
+
+// Port is a function that will return the port Keycloak is exposed on, falling back to 8080 when one is not set.
+func Port(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneKeycloak.Port != "" {
+		return terraformConfig.StandaloneKeycloak.Port
+	}
+
+	return defaultPort
+}
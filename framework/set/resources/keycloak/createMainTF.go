@@ -0,0 +1,121 @@
+package keycloak
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/sirupsen/logrus"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	keycloak         = "keycloak"
+	keycloakPublicIP = "keycloak_public_ip"
+
+	samlDescriptorPath = "/protocol/saml/descriptor"
+	terraformConst     = "terraform"
+)
+
+// CreateMainTF is a helper function that will create the main.tf file for creating a standalone Keycloak server. The realm is
+// registered for the given Rancher server, and the SAML metadata of the realm is fetched once Keycloak is ready.
+func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (*Server, error) {
+	var file *os.File
+	file = sanity.OpenFile(file, keyPath)
+	defer file.Close()
+
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
+	tfBlockBody := tfBlock.Body()
+
+	instances := []string{keycloak}
+
+	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
+	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating resources. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	keycloakPublicIP := terraform.Output(t, terraformOptions, keycloakPublicIP)
+
+	spCertificate, err := generateSPCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret := namegen.RandStringLower(32)
+
+	file = sanity.OpenFile(file, keyPath)
+	logrus.Infof("Creating Keycloak...")
+	file, err = CreateKeycloak(file, newFile, rootBody, terraformConfig, terratestConfig, keycloakPublicIP, rancherConfig.Host, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating Keycloak. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	server := newServer(terraformConfig, keycloakPublicIP, clientSecret, spCertificate)
+
+	server.IDPMetadata, err = fetchIDPMetadata(server.Issuer + samlDescriptorPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// fetchIDPMetadata is a helper function that will wait for the realm to be served and return its SAML IdP metadata.
+func fetchIDPMetadata(descriptorURL string) (string, error) {
+	var metadata string
+
+	logrus.Infof("Waiting for the SAML metadata of the realm at %s...", descriptorURL)
+	err := kwait.PollUntilContextTimeout(context.TODO(), 5*time.Second, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		resp, err := http.Get(descriptorURL)
+		if err != nil {
+			return false, nil
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return false, nil
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return false, nil
+		}
+
+		metadata = string(body)
+
+		return true, nil
+	})
+
+	return metadata, err
+}
@@ -0,0 +1,90 @@
+package keycloak
+
+import (
+	"fmt"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/config/authproviders"
+)
+
+const (
+	Realm        = "tfp"
+	Group        = "tfp-group"
+	OIDCClientID = "tfp-rancher"
+
+	displayNameField = "displayName"
+	groupsField      = "member"
+	uidField         = "uid"
+	scopes           = "openid profile email"
+
+	samlACSPath      = "/v1-saml/keycloak/saml/acs"
+	samlEntityIDPath = "/v1-saml/keycloak/saml/metadata"
+)
+
+// Server holds the endpoints of the standalone Keycloak realm, the credentials of its test user and the SAML service provider
+// certificate generated for Rancher.
+type Server struct {
+	Host         string
+	URL          string
+	Issuer       string
+	ClientSecret string
+	IDPMetadata  string
+	SPCert       string
+	SPKey        string
+	Username     string
+	Password     string
+}
+
+// newServer is a helper function that will return the endpoints of the Keycloak realm served on the given host.
+func newServer(terraformConfig *config.TerraformConfig, host, clientSecret string, spCertificate *spCertificate) *Server {
+	url := fmt.Sprintf("http://%s:%s", host, Port(terraformConfig))
+
+	return &Server{
+		Host:         host,
+		URL:          url,
+		Issuer:       url + "/realms/" + Realm,
+		ClientSecret: clientSecret,
+		SPCert:       spCertificate.cert,
+		SPKey:        spCertificate.key,
+		Username:     terraformConfig.StandaloneKeycloak.Username,
+		Password:     terraformConfig.StandaloneKeycloak.Password,
+	}
+}
+
+// OIDCConfig is a function that will return the keycloakoidc and genericoidc auth config of the realm client.
+func (s *Server) OIDCConfig() authproviders.OIDCConfig {
+	return authproviders.OIDCConfig{
+		AuthEndpoint:     s.Issuer + "/protocol/openid-connect/auth",
+		ClientID:         OIDCClientID,
+		ClientSecret:     s.ClientSecret,
+		Issuer:           s.Issuer,
+		JWKSURL:          s.Issuer + "/protocol/openid-connect/certs",
+		Scopes:           scopes,
+		TokenEndpoint:    s.Issuer + "/protocol/openid-connect/token",
+		UserInfoEndpoint: s.Issuer + "/protocol/openid-connect/userinfo",
+	}
+}
+
+// SAMLConfig is a function that will return the keycloak SAML auth config of the realm client registered for the Rancher server.
+func (s *Server) SAMLConfig(rancherHost string) authproviders.KeycloakConfig {
+	return authproviders.KeycloakConfig{
+		DisplayNameField:   displayNameField,
+		EntityID:           SAMLEntityID(rancherHost),
+		GroupsField:        groupsField,
+		IdpMetadataContent: s.IDPMetadata,
+		SPCert:             s.SPCert,
+		SPKey:              s.SPKey,
+		UIDField:           uidField,
+		UserNameField:      uidField,
+	}
+}
+
+// SAMLEntityID is a function that will return the SAML entity ID of the Rancher server, which is the client ID of the realm client.
+func SAMLEntityID(rancherHost string) string {
+	return "https://" + rancherHost + samlEntityIDPath
+}
+
+// samlACSURL is a helper function that will return the assertion consumer service URL of the Rancher server.
+func samlACSURL(rancherHost string) string {
+	return "https://" + rancherHost + samlACSPath
+}
@@ -0,0 +1,154 @@
+#!/bin/bash
+
+USER=$1
+ADMIN_USERNAME=$2
+ADMIN_PASSWORD=$3
+PORT=$4
+HOST=$5
+IMAGE=$6
+REALM=$7
+USERNAME=$8
+PASSWORD=$9
+GROUP=${10}
+OIDC_CLIENT_ID=${11}
+OIDC_CLIENT_SECRET=${12}
+SAML_ENTITY_ID=${13}
+SAML_ACS_URL=${14}
+KEYCLOAK_DIR="/home/${USER}/keycloak"
+
+set -e
+
+if ! command -v docker &> /dev/null; then
+    echo "Installing Docker..."
+    curl -fsSL https://get.docker.com | sudo sh
+fi
+
+sudo mkdir -p ${KEYCLOAK_DIR}/import
+
+echo "Writing realm ${REALM}..."
+sudo tee ${KEYCLOAK_DIR}/import/${REALM}.json > /dev/null <<EOT
+{
+  "realm": "${REALM}",
+  "enabled": true,
+  "sslRequired": "none",
+  "groups": [
+    {
+      "name": "${GROUP}"
+    }
+  ],
+  "users": [
+    {
+      "username": "${USERNAME}",
+      "enabled": true,
+      "email": "${USERNAME}@tfp-automation.local",
+      "emailVerified": true,
+      "firstName": "tfp",
+      "lastName": "automation",
+      "credentials": [
+        {
+          "type": "password",
+          "value": "${PASSWORD}",
+          "temporary": false
+        }
+      ],
+      "groups": [
+        "/${GROUP}"
+      ]
+    }
+  ],
+  "clients": [
+    {
+      "clientId": "${OIDC_CLIENT_ID}",
+      "enabled": true,
+      "protocol": "openid-connect",
+      "publicClient": false,
+      "secret": "${OIDC_CLIENT_SECRET}",
+      "standardFlowEnabled": true,
+      "redirectUris": ["*"],
+      "protocolMappers": [
+        {
+          "name": "groups",
+          "protocol": "openid-connect",
+          "protocolMapper": "oidc-group-membership-mapper",
+          "config": {
+            "claim.name": "groups",
+            "full.path": "false",
+            "id.token.claim": "true",
+            "access.token.claim": "true",
+            "userinfo.token.claim": "true"
+          }
+        }
+      ]
+    },
+    {
+      "clientId": "${SAML_ENTITY_ID}",
+      "enabled": true,
+      "protocol": "saml",
+      "redirectUris": ["*"],
+      "attributes": {
+        "saml.assertion.signature": "true",
+        "saml.authnstatement": "true",
+        "saml.client.signature": "false",
+        "saml.force.post.binding": "true",
+        "saml.server.signature": "true",
+        "saml_assertion_consumer_url_post": "${SAML_ACS_URL}",
+        "saml_force_name_id_format": "true",
+        "saml_name_id_format": "username"
+      },
+      "protocolMappers": [
+        {
+          "name": "uid",
+          "protocol": "saml",
+          "protocolMapper": "saml-user-property-mapper",
+          "config": {
+            "user.attribute": "username",
+            "attribute.name": "uid",
+            "attribute.nameformat": "Basic"
+          }
+        },
+        {
+          "name": "displayName",
+          "protocol": "saml",
+          "protocolMapper": "saml-user-property-mapper",
+          "config": {
+            "user.attribute": "firstName",
+            "attribute.name": "displayName",
+            "attribute.nameformat": "Basic"
+          }
+        },
+        {
+          "name": "member",
+          "protocol": "saml",
+          "protocolMapper": "saml-group-membership-mapper",
+          "config": {
+            "attribute.name": "member",
+            "attribute.nameformat": "Basic",
+            "full.path": "false",
+            "single": "false"
+          }
+        }
+      ]
+    }
+  ]
+}
+EOT
+
+echo "Starting Keycloak..."
+sudo docker run -d --name keycloak --restart always \
+    -p ${PORT}:8080 \
+    -e KC_BOOTSTRAP_ADMIN_USERNAME=${ADMIN_USERNAME} \
+    -e KC_BOOTSTRAP_ADMIN_PASSWORD=${ADMIN_PASSWORD} \
+    -e KC_HOSTNAME=http://${HOST}:${PORT} \
+    -v ${KEYCLOAK_DIR}/import:/opt/keycloak/data/import:ro \
+    ${IMAGE} start-dev --import-realm
+
+echo "Waiting for Keycloak to be ready..."
+for i in $(seq 1 60); do
+    if curl -sf http://localhost:${PORT}/realms/${REALM}/.well-known/openid-configuration > /dev/null; then
+        break
+    fi
+
+    sleep 5
+done
+
+echo "Keycloak realm ${REALM} is available at http://${HOST}:${PORT}/realms/${REALM}"
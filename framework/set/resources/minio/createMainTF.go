@@ -0,0 +1,70 @@
+package minio
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	minio         = "minio"
+	minioPublicIP = "minio_public_ip"
+
+	terraformConst = "terraform"
+)
+
+// CreateMainTF is a helper function that will create the main.tf file for creating a standalone MinIO server. The endpoint and CA
+// of the returned server can be used as the etcd S3 endpoint and endpoint CA of downstream clusters.
+func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (*Server, error) {
+	var file *os.File
+	file = sanity.OpenFile(file, keyPath)
+	defer file.Close()
+
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
+	tfBlockBody := tfBlock.Body()
+
+	instances := []string{minio}
+
+	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
+	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating resources. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	minioPublicIP := terraform.Output(t, terraformOptions, minioPublicIP)
+
+	file = sanity.OpenFile(file, keyPath)
+	logrus.Infof("Creating MinIO server...")
+	file, err = CreateMinIO(file, newFile, rootBody, terraformConfig, terratestConfig, minioPublicIP)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating MinIO server. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return nil, err
+	}
+
+	return newServer(terraformConfig, minioPublicIP)
+}
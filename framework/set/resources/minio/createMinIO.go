@@ -0,0 +1,84 @@
+package minio
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	installMinIO = "install_minio"
+
+	defaultImage       = "minio/minio:RELEASE.2025-04-22T22-12-26Z"
+	defaultClientImage = "minio/mc:RELEASE.2025-04-16T18-13-26Z"
+	defaultPort        = "9000"
+)
+
+// CreateMinIO is a function that will set the MinIO server configurations in the main.tf file.
+func CreateMinIO(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, minioPublicIP string) (*os.File, error) {
+	userDir, _ := rancher2.SetKeyPath(keypath.MinIOKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+
+	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/minio/setup.sh")
+
+	scriptContent, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, minioPublicIP, installMinIO)
+
+	command := "bash -c '/tmp/setup.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.StandaloneMinIO.AccessKey + " " +
+		terraformConfig.StandaloneMinIO.SecretKey + " " + terraformConfig.StandaloneMinIO.Bucket + " " + Image(terraformConfig) + " " +
+		Port(terraformConfig) + " " + minioPublicIP + " " + ClientImage(terraformConfig) + " || true'"
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo '" + string(scriptContent) + "' > /tmp/setup.sh"),
+		cty.StringVal("chmod +x /tmp/setup.sh"),
+		cty.StringVal(command),
+	}))
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Image is a function that will return the MinIO image, falling back to a pinned upstream release when one is not set.
+func Image(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneMinIO.Image != "" {
+		return terraformConfig.StandaloneMinIO.Image
+	}
+
+	return defaultImage
+}
+
+// ClientImage is a function that will return the MinIO client image used to create the bucket, falling back to a pinned
+// upstream release when one is not set.
+func ClientImage(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneMinIO.ClientImage != "" {
+		return terraformConfig.StandaloneMinIO.ClientImage
+	}
+
+	return defaultClientImage
+}
+
+// Port is a function that will return the port MinIO is exposed on, falling back to the MinIO default when one is not set.
+func Port(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.StandaloneMinIO.Port != "" {
+		return terraformConfig.StandaloneMinIO.Port
+	}
+
+	return defaultPort
+}
@@ -0,0 +1,44 @@
+package minio
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rancher/shepherd/pkg/nodes"
+	"github.com/rancher/tfp-automation/config"
+)
+
+const (
+	readCACert = "sudo cat /home/%s/minio/certs/public.crt"
+)
+
+// Server holds the endpoint of the standalone MinIO server and the PEM encoded CA its self-signed certificate can be trusted with.
+type Server struct {
+	Endpoint string
+	CACert   string
+}
+
+// newServer is a helper function that will return the endpoint of the MinIO server served on the given host, along with the
+// self-signed certificate read back from the host over SSH.
+func newServer(terraformConfig *config.TerraformConfig, host string) (*Server, error) {
+	sshKey, err := os.ReadFile(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &nodes.Node{
+		PublicIPAddress: host,
+		SSHUser:         terraformConfig.Standalone.OSUser,
+		SSHKey:          sshKey,
+	}
+
+	caCert, err := node.ExecuteCommand(fmt.Sprintf(readCACert, terraformConfig.Standalone.OSUser))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Endpoint: fmt.Sprintf("%s:%s", host, Port(terraformConfig)),
+		CACert:   caCert,
+	}, nil
+}
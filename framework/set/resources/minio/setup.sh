@@ -0,0 +1,51 @@
+#!/bin/bash
+
+USER=$1
+ACCESS_KEY=$2
+SECRET_KEY=$3
+BUCKET=$4
+IMAGE=$5
+PORT=$6
+HOST=$7
+CLIENT_IMAGE=$8
+MINIO_DIR="/home/${USER}/minio"
+
+set -e
+
+if ! command -v docker &> /dev/null; then
+    echo "Installing Docker..."
+    curl -fsSL https://get.docker.com | sudo sh
+fi
+
+echo "Generating self-signed certificate for ${HOST}..."
+sudo mkdir -p ${MINIO_DIR}/data ${MINIO_DIR}/certs
+sudo openssl req -x509 -nodes -newkey rsa:2048 -days 365 \
+    -keyout ${MINIO_DIR}/certs/private.key \
+    -out ${MINIO_DIR}/certs/public.crt \
+    -subj "/CN=${HOST}" \
+    -addext "subjectAltName=IP:${HOST}"
+
+echo "Starting MinIO..."
+sudo docker run -d --name minio --restart always \
+    -p ${PORT}:9000 \
+    -e MINIO_ROOT_USER=${ACCESS_KEY} \
+    -e MINIO_ROOT_PASSWORD=${SECRET_KEY} \
+    -v ${MINIO_DIR}/data:/data \
+    -v ${MINIO_DIR}/certs:/root/.minio/certs \
+    ${IMAGE} server /data --certs-dir /root/.minio/certs
+
+echo "Waiting for MinIO to be ready..."
+for i in $(seq 1 30); do
+    if curl -sk https://localhost:${PORT}/minio/health/live; then
+        break
+    fi
+
+    sleep 5
+done
+
+echo "Creating bucket ${BUCKET}..."
+sudo docker run --rm --network host --entrypoint sh ${CLIENT_IMAGE} -c \
+    "mc alias set local https://localhost:${PORT} ${ACCESS_KEY} ${SECRET_KEY} --insecure && mc mb --ignore-existing --insecure local/${BUCKET}"
+
+echo "MinIO is available at https://${HOST}:${PORT} with the CA:"
+sudo cat ${MINIO_DIR}/certs/public.crt
@@ -2,12 +2,10 @@ package aws
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
@@ -29,17 +27,13 @@ const (
 
 // CreateWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file.
 func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	hostnamePrefix string) {
+	hostnamePrefix, windowsVersion string) {
 	configBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AwsInstance, hostnamePrefix + "-windows"})
 	configBlockBody := configBlock.Body()
 
 	configBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(terratestConfig.WindowsNodeCount))
 
-	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2019") {
-		configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2019AMI))
-	} else if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2022") {
-		configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2022AMI))
-	}
+	configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(WindowsAMI(terraformConfig, windowsVersion)))
 
 	configBlockBody.SetAttributeValue(defaults.InstanceType, cty.StringVal(terraformConfig.AWSConfig.WindowsInstanceType))
 	configBlockBody.SetAttributeValue(defaults.SubnetId, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
@@ -78,11 +72,7 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.WinRM))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
-	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2019") {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2022") {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(WindowsPassword(terraformConfig, windowsVersion)))
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
@@ -119,17 +109,13 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 }
 
 // CreateAirgappedWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file.
-func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string) {
+func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix, windowsVersion string) {
 	configBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AwsInstance, hostnamePrefix})
 	configBlockBody := configBlock.Body()
 
 	configBlockBody.SetAttributeValue(defaults.AssociatePublicIPAddress, cty.BoolVal(false))
 
-	if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2019) {
-		configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2019AMI))
-	} else if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2022) {
-		configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2022AMI))
-	}
+	configBlockBody.SetAttributeValue(defaults.Ami, cty.StringVal(WindowsAMI(terraformConfig, windowsVersion)))
 
 	configBlockBody.SetAttributeValue(defaults.InstanceType, cty.StringVal(terraformConfig.AWSConfig.WindowsInstanceType))
 	configBlockBody.SetAttributeValue(defaults.SubnetId, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
@@ -167,11 +153,7 @@ func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.WinRM))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
-	if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(WindowsPassword(terraformConfig, windowsVersion)))
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
@@ -185,3 +167,31 @@ func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig
 
 	connectionBlockBody.SetAttributeValue(defaults.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
 }
+
+// WindowsAMI is a function that will return the AWS AMI of the given Windows Server version.
+func WindowsAMI(terraformConfig *config.TerraformConfig, windowsVersion string) string {
+	switch windowsVersion {
+	case defaults.Windows2019:
+		return terraformConfig.AWSConfig.Windows2019AMI
+	case defaults.Windows2022:
+		return terraformConfig.AWSConfig.Windows2022AMI
+	case defaults.Windows2025:
+		return terraformConfig.AWSConfig.Windows2025AMI
+	}
+
+	return ""
+}
+
+// WindowsPassword is a function that will return the Administrator password of the AWS AMI of the given Windows Server version.
+func WindowsPassword(terraformConfig *config.TerraformConfig, windowsVersion string) string {
+	switch windowsVersion {
+	case defaults.Windows2019:
+		return terraformConfig.AWSConfig.Windows2019Password
+	case defaults.Windows2022:
+		return terraformConfig.AWSConfig.Windows2022Password
+	case defaults.Windows2025:
+		return terraformConfig.AWSConfig.Windows2025Password
+	}
+
+	return ""
+}
@@ -27,24 +27,37 @@ func CreateHarvesterResources(file *os.File, newFile *hclwrite.File, tfBlockBody
 	}
 
 	rootBody.AppendNewline()
+
+	err := CreateKubeconfigFile(file, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+	return file, err
+}
+
+// CreateKubeconfigFile is a helper function that will write the Harvester kubeconfig to local.yaml next to the main.tf file.
+func CreateKubeconfigFile(file *os.File, terraformConfig *config.TerraformConfig) error {
 	dirList := file.Name()
 
 	localFile, err := os.Create(strings.Join(strings.Split(dirList, "main.tf"), "/") + "/local.yaml")
 	if err != nil {
 		logrus.Infof("Failed create local.yaml kubeconfig. Error: %v", err)
-		return nil, err
+		return err
 	}
 
+	defer localFile.Close()
+
 	_, err = localFile.Write([]byte(terraformConfig.HarvesterCredentials.KubeconfigContent))
 	if err != nil {
 		logrus.Infof("Failed write to local.yaml. Error: %v", err)
-		return nil, err
+		return err
 	}
 
-	_, err = file.Write(newFile.Bytes())
-	if err != nil {
-		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
-		return nil, err
-	}
-	return file, err
+	return nil
 }
@@ -83,6 +83,11 @@ func CreateLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 	}
 	localBlockBody.SetAttributeRaw(defaults.ModuleRelPath, relPathModule)
 
+	SetCloudInitLocal(localBlockBody, terraformConfig)
+}
+
+// SetCloudInitLocal will set the cloud-init local used by the Harvester virtual machines in the given local block.
+func SetCloudInitLocal(localBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	publicKey := getPublicSSHKey(terraformConfig.PrivateKeyPath)
 	localBlockBody.SetAttributeRaw(defaults.CloudInit, hclwrite.TokensForTraversal(hcl.Traversal{
 		hcl.TraverseRoot{
@@ -0,0 +1,186 @@
+package vsphere
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	adminPassword      = "admin_password"
+	autoLogon          = "auto_logon"
+	autoLogonCount     = "auto_logon_count"
+	computerName       = "computer_name"
+	firmware           = "firmware"
+	runOnceCommandList = "run_once_command_list"
+	windowsOptions     = "windows_options"
+	windowsTemplate    = defaults.VsphereVirtualMachineTemplate + "_windows"
+
+	// Windows computer names are limited to 15 characters, so the resource prefix is truncated to leave room for the index.
+	maxComputerNamePrefix = 10
+)
+
+// The run once commands enable WinRM on the first logon, so the nodes can be registered the same way as the EC2 Windows nodes.
+var winRMCommands = []string{
+	`winrm quickconfig -q`,
+	`winrm set winrm/config/service @{AllowUnencrypted="true"}`,
+	`winrm set winrm/config/service/auth @{Basic="true"}`,
+	`netsh advfirewall firewall add rule name="WinRM HTTP" dir=in action=allow protocol=TCP localport=5985`,
+	`netsh advfirewall firewall add rule name="WinRM HTTPS" dir=in action=allow protocol=TCP localport=5986`,
+}
+
+// CreateVsphereWindowsVirtualMachineTemplate is a function that will set the vSphere Windows virtual machine template configuration
+// in the main.tf file.
+func CreateVsphereWindowsVirtualMachineTemplate(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, dataCenterValue hclwrite.Tokens,
+	windowsVersion string) {
+	vmTemplateBlock := rootBody.AppendNewBlock(defaults.Data, []string{defaults.VsphereVirtualMachine, windowsTemplate})
+	vmTemplateBlockBody := vmTemplateBlock.Body()
+
+	vmTemplateBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(WindowsTemplate(terraformConfig, windowsVersion)))
+	vmTemplateBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
+}
+
+// CreateVsphereWindowsVirtualMachines is a function that will set the vSphere Windows virtual machines configurations in the main.tf file.
+// The virtual machines are cloned from the Windows template and customized to enable WinRM.
+func CreateVsphereWindowsVirtualMachines(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) {
+	vmBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.VsphereVirtualMachine, hostnamePrefix + "-windows"})
+	vmBlockBody := vmBlock.Body()
+
+	vmBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(terratestConfig.WindowsNodeCount))
+
+	vmNameExpression := fmt.Sprintf(` "%s-windows-${%s.%s}"`, hostnamePrefix, defaults.Count, defaults.Index)
+	vmNameValue := hclwrite.Tokens{
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(vmNameExpression)},
+	}
+
+	vmBlockBody.SetAttributeRaw(defaults.ResourceName, vmNameValue)
+
+	resourcePoolExpression := defaults.Data + `.` + defaults.VsphereComputeCluster + `.` + defaults.VsphereComputeCluster + `.` + resourcePoolID
+	vmBlockBody.SetAttributeRaw(resourcePoolID, hclwrite.TokensForIdentifier(resourcePoolExpression))
+
+	dataStoreExpression := defaults.Data + `.` + defaults.VsphereDatastore + `.` + defaults.VsphereDatastore + `.id`
+	vmBlockBody.SetAttributeRaw(datastoreID, hclwrite.TokensForIdentifier(dataStoreExpression))
+	vmBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(terraformConfig.VsphereConfig.Folder))
+
+	cpuCount, err := strconv.ParseInt(terraformConfig.VsphereConfig.CPUCount, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid CPU count value: %s", terraformConfig.VsphereConfig.CPUCount))
+	}
+
+	vmBlockBody.SetAttributeValue(defaults.NumCPUs, cty.NumberIntVal(cpuCount))
+
+	memory, err := strconv.ParseInt(terraformConfig.VsphereConfig.MemorySize, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid memory size value: %s", terraformConfig.VsphereConfig.MemorySize))
+	}
+
+	vmBlockBody.SetAttributeValue(defaults.Memory, cty.NumberIntVal(memory))
+
+	templateExpression := defaults.Data + `.` + defaults.VsphereVirtualMachine + `.` + windowsTemplate
+	vmBlockBody.SetAttributeRaw(guestID, hclwrite.TokensForIdentifier(templateExpression+`.`+guestID))
+	vmBlockBody.SetAttributeRaw(firmware, hclwrite.TokensForIdentifier(templateExpression+`.`+firmware))
+
+	vmBlockBody.AppendNewline()
+
+	networkBlock := vmBlockBody.AppendNewBlock(defaults.NetworkInterface, nil)
+	networkBlockBody := networkBlock.Body()
+
+	networkExpression := defaults.Data + `.` + defaults.VsphereNetwork + `.` + defaults.VsphereNetwork + `.id`
+	networkBlockBody.SetAttributeRaw(defaults.NetworkID, hclwrite.TokensForIdentifier(networkExpression))
+	networkBlockBody.SetAttributeRaw(adapterType, hclwrite.TokensForIdentifier(templateExpression+`.`+networkInterfaceTypes+`[0]`))
+	vmBlockBody.AppendNewline()
+
+	diskBlock := vmBlockBody.AppendNewBlock(defaults.Disk, nil)
+	diskBlockBody := diskBlock.Body()
+
+	diskLabelExpression := fmt.Sprintf(` "%s-windows-${%s.%s}"`, hostnamePrefix, defaults.Count, defaults.Index)
+	diskLabelValue := hclwrite.Tokens{
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(diskLabelExpression)},
+	}
+
+	diskBlockBody.SetAttributeRaw(defaults.Label, diskLabelValue)
+
+	diskSize, err := strconv.ParseInt(terraformConfig.VsphereConfig.DiskSize, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid disk size value: %s", terraformConfig.VsphereConfig.DiskSize))
+	}
+
+	diskBlockBody.SetAttributeValue(defaults.Size, cty.NumberIntVal(diskSize))
+	vmBlockBody.AppendNewline()
+
+	cloneBlock := vmBlockBody.AppendNewBlock(clone, nil)
+	cloneBlockBody := cloneBlock.Body()
+
+	cloneBlockBody.SetAttributeRaw(templateUUID, hclwrite.TokensForIdentifier(templateExpression+`.id`))
+	cloneBlockBody.AppendNewline()
+
+	customizeBlock := cloneBlockBody.AppendNewBlock(defaults.Customize, nil)
+	customizeBlockBody := customizeBlock.Body()
+
+	windowsOptionsBlock := customizeBlockBody.AppendNewBlock(windowsOptions, nil)
+	windowsOptionsBlockBody := windowsOptionsBlock.Body()
+
+	computerNamePrefix := terraformConfig.ResourcePrefix
+	if len(computerNamePrefix) > maxComputerNamePrefix {
+		computerNamePrefix = computerNamePrefix[:maxComputerNamePrefix]
+	}
+
+	computerNameExpression := fmt.Sprintf(` "%s-w${%s.%s}"`, computerNamePrefix, defaults.Count, defaults.Index)
+	computerNameValue := hclwrite.Tokens{
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(computerNameExpression)},
+	}
+
+	windowsOptionsBlockBody.SetAttributeRaw(computerName, computerNameValue)
+	windowsOptionsBlockBody.SetAttributeValue(adminPassword, cty.StringVal(terraformConfig.VsphereConfig.WindowsPassword))
+	windowsOptionsBlockBody.SetAttributeValue(autoLogon, cty.BoolVal(true))
+	windowsOptionsBlockBody.SetAttributeValue(autoLogonCount, cty.NumberIntVal(1))
+
+	var commands []cty.Value
+	for _, command := range winRMCommands {
+		commands = append(commands, cty.StringVal(command))
+	}
+
+	windowsOptionsBlockBody.SetAttributeValue(runOnceCommandList, cty.ListVal(commands))
+	customizeBlockBody.AppendNewline()
+
+	customizeBlockBody.AppendNewBlock(defaults.NetworkInterface, nil)
+	vmBlockBody.AppendNewline()
+
+	connectionBlock := vmBlockBody.AppendNewBlock(defaults.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.WinRM))
+	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereConfig.WindowsUser))
+	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.VsphereConfig.WindowsPassword))
+	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
+	connectionBlockBody.SetAttributeValue(defaults.UseNTLM, cty.BoolVal(true))
+	connectionBlockBody.SetAttributeRaw(defaults.Host, hclwrite.TokensForIdentifier(defaults.Self+`.`+defaults.DefaultIPAddress))
+	vmBlockBody.AppendNewline()
+
+	provisionerBlock := vmBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+	provisionerBlockBody := provisionerBlock.Body()
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo Connected!!!"),
+	}))
+}
+
+// WindowsTemplate is a function that will return the vSphere template of the given Windows Server version.
+func WindowsTemplate(terraformConfig *config.TerraformConfig, windowsVersion string) string {
+	switch windowsVersion {
+	case defaults.Windows2019:
+		return terraformConfig.VsphereConfig.Windows2019Template
+	case defaults.Windows2022:
+		return terraformConfig.VsphereConfig.Windows2022Template
+	case defaults.Windows2025:
+		return terraformConfig.VsphereConfig.Windows2025Template
+	}
+
+	return ""
+}
@@ -0,0 +1,126 @@
+package apps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	appV2     = "rancher2_app_v2"
+	catalogV2 = "rancher2_catalog_v2"
+
+	chartName     = "chart_name"
+	chartVersion  = "chart_version"
+	cleanupOnFail = "cleanup_on_fail"
+	gitBranch     = "git_branch"
+	gitRepo       = "git_repo"
+	repoName      = "repo_name"
+	url           = "url"
+	values        = "values"
+	wait          = "wait"
+
+	rancherChartsRepo = "rancher-charts"
+)
+
+// SetApps is a function that will set the rancher2_catalog_v2 and rancher2_app_v2 configurations of the cluster in the main.tf file.
+// A catalog is only created for repos that set a repoURL or gitRepo, otherwise the chart is installed from an existing repo.
+// Apps are installed in the order they are listed, so charts such as CRD charts must come before the apps that need them.
+func SetApps(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	clusterID := rancher2.ClusterIDExpression(terraformConfig)
+	catalogs := map[string]string{}
+	previousApp := ""
+
+	for _, app := range terraformConfig.Apps {
+		if app.Name == "" || app.Namespace == "" {
+			return fmt.Errorf("Name and namespace must be set for every app of cluster: %v", terraformConfig.ResourcePrefix)
+		}
+
+		chart := app.ChartName
+		if chart == "" {
+			chart = app.Name
+		}
+
+		repo := app.Repo
+		if repo == "" {
+			repo = rancherChartsRepo
+		}
+
+		repoNameValue := hclwrite.TokensForValue(cty.StringVal(repo))
+
+		if app.RepoURL != "" || app.GitRepo != "" {
+			catalogName, ok := catalogs[repo]
+			if !ok {
+				catalogName = terraformConfig.ResourcePrefix + "-" + repo
+				setCatalog(rootBody, clusterID, catalogName, repo, app)
+				catalogs[repo] = catalogName
+			}
+
+			repoNameValue = hclwrite.TokensForIdentifier(catalogV2 + "." + catalogName + "." + defaults.ResourceName)
+		}
+
+		appName := terraformConfig.ResourcePrefix + "-" + app.Name
+		appBlock := rootBody.AppendNewBlock(defaults.Resource, []string{appV2, appName})
+		appBlockBody := appBlock.Body()
+
+		appBlockBody.SetAttributeRaw(defaults.RancherClusterID, hclwrite.TokensForIdentifier(clusterID))
+		appBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(app.Name))
+		appBlockBody.SetAttributeValue(defaults.Namespace, cty.StringVal(app.Namespace))
+		appBlockBody.SetAttributeRaw(repoName, repoNameValue)
+		appBlockBody.SetAttributeValue(chartName, cty.StringVal(chart))
+
+		if app.Version != "" {
+			appBlockBody.SetAttributeValue(chartVersion, cty.StringVal(app.Version))
+		}
+
+		if app.Values != "" {
+			valuesValue := hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: "<<EOF\n" + strings.TrimSuffix(app.Values, "\n") + "\nEOF"},
+			})
+
+			appBlockBody.SetAttributeRaw(values, valuesValue)
+		}
+
+		appBlockBody.SetAttributeValue(cleanupOnFail, cty.BoolVal(true))
+		appBlockBody.SetAttributeValue(wait, cty.BoolVal(true))
+
+		if previousApp != "" {
+			dependsOn := hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + appV2 + `.` + previousApp + `]`)},
+			}
+
+			appBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOn)
+		}
+
+		previousApp = appName
+
+		rootBody.AppendNewline()
+	}
+
+	return nil
+}
+
+// setCatalog is a helper function that will set the rancher2_catalog_v2 configuration of a chart repo in the main.tf file.
+func setCatalog(rootBody *hclwrite.Body, clusterID, catalogName, repo string, app config.App) {
+	catalogBlock := rootBody.AppendNewBlock(defaults.Resource, []string{catalogV2, catalogName})
+	catalogBlockBody := catalogBlock.Body()
+
+	catalogBlockBody.SetAttributeRaw(defaults.RancherClusterID, hclwrite.TokensForIdentifier(clusterID))
+	catalogBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(repo))
+
+	if app.GitRepo != "" {
+		catalogBlockBody.SetAttributeValue(gitRepo, cty.StringVal(app.GitRepo))
+		catalogBlockBody.SetAttributeValue(gitBranch, cty.StringVal(app.GitBranch))
+	} else {
+		catalogBlockBody.SetAttributeValue(url, cty.StringVal(app.RepoURL))
+	}
+
+	rootBody.AppendNewline()
+}
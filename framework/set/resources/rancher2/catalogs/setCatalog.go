@@ -0,0 +1,93 @@
+package catalogs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/chartrepo"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	catalogV2 = "rancher2_catalog_v2"
+
+	caBundle        = "ca_bundle"
+	clusterID       = "cluster_id"
+	gitBranch       = "git_branch"
+	gitRepo         = "git_repo"
+	password        = "password"
+	secretName      = "secret_name"
+	secretNamespace = "secret_namespace"
+	url             = "url"
+	username        = "username"
+
+	basicAuthSecret = "kubernetes.io/basic-auth"
+	cattleSystem    = "cattle-system"
+	chartRepoSecret = "chart-repo"
+
+	Git  = "git"
+	HTTP = "http"
+)
+
+// SetCatalog is a function that will set the rancher2_catalog_v2 configuration of the standalone chart repository in the main.tf
+// file. The repo is added to the local cluster with its basic auth credentials in a rancher2_secret_v2 and trusts the CA of the server.
+func SetCatalog(terraformConfig *config.TerraformConfig, server *chartrepo.Server, repoType string, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File) error {
+	secretResourceName := terraformConfig.ResourcePrefix + "-" + chartRepoSecret
+
+	secretBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.SecretV2, secretResourceName})
+	secretBlockBody := secretBlock.Body()
+
+	secretBlockBody.SetAttributeValue(clusterID, cty.StringVal(defaults.Local))
+	secretBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(secretResourceName))
+	secretBlockBody.SetAttributeValue(defaults.Namespace, cty.StringVal(cattleSystem))
+	secretBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(basicAuthSecret))
+
+	dataBlock := secretBlockBody.AppendNewBlock(defaults.Data+" =", nil)
+	dataBlockBody := dataBlock.Body()
+
+	dataBlockBody.SetAttributeValue(password, cty.StringVal(terraformConfig.StandaloneChartRepo.Password))
+	dataBlockBody.SetAttributeValue(username, cty.StringVal(terraformConfig.StandaloneChartRepo.Username))
+
+	rootBody.AppendNewline()
+
+	catalogBlock := rootBody.AppendNewBlock(defaults.Resource, []string{catalogV2, CatalogName(terraformConfig, repoType)})
+	catalogBlockBody := catalogBlock.Body()
+
+	catalogBlockBody.SetAttributeValue(clusterID, cty.StringVal(defaults.Local))
+	catalogBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(CatalogName(terraformConfig, repoType)))
+
+	switch repoType {
+	case Git:
+		catalogBlockBody.SetAttributeValue(gitRepo, cty.StringVal(server.GitURL))
+		catalogBlockBody.SetAttributeValue(gitBranch, cty.StringVal(chartrepo.GitBranch))
+	case HTTP:
+		catalogBlockBody.SetAttributeValue(url, cty.StringVal(server.HelmURL))
+	default:
+		return fmt.Errorf("Unsupported catalog repo type: %v", repoType)
+	}
+
+	catalogBlockBody.SetAttributeValue(caBundle, cty.StringVal(base64.StdEncoding.EncodeToString([]byte(server.CABundle))))
+
+	secret := defaults.SecretV2 + "." + secretResourceName
+	catalogBlockBody.SetAttributeRaw(secretName, hclwrite.TokensForIdentifier(secret+"."+defaults.ResourceName))
+	catalogBlockBody.SetAttributeRaw(secretNamespace, hclwrite.TokensForIdentifier(secret+"."+defaults.Namespace))
+
+	_, err := file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write catalog configurations to main.tf file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// CatalogName is a function that will return the name of the catalog of the given repo type.
+func CatalogName(terraformConfig *config.TerraformConfig, repoType string) string {
+	return terraformConfig.ResourcePrefix + "-" + repoType
+}
@@ -0,0 +1,37 @@
+package rancher2
+
+import (
+	"strings"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+)
+
+const (
+	clusterSync = "rancher2_cluster_sync"
+	clusterV1ID = "cluster_v1_id"
+	id          = "id"
+)
+
+// ClusterIDExpression is a function that will return the expression of the v1 cluster ID of the cluster in the main.tf file. Hosted
+// clusters are rendered as the rancher2_cluster.rancher2_cluster resource, RKE1 node driver clusters are referenced through their cluster
+// sync, so resources depending on it are only created once the cluster is active, and RKE2/K3s clusters through their rancher2_cluster_v2.
+func ClusterIDExpression(terraformConfig *config.TerraformConfig) string {
+	if strings.Contains(terraformConfig.Module, clustertypes.AKS) || strings.Contains(terraformConfig.Module, clustertypes.EKS) ||
+		strings.Contains(terraformConfig.Module, clustertypes.GKE) {
+		return defaults.Cluster + "." + defaults.Cluster + "." + id
+	}
+
+	// Imported clusters and RKE1 custom clusters are rendered as a rancher2_cluster without a cluster sync.
+	if strings.Contains(terraformConfig.Module, defaults.Import) || (strings.Contains(terraformConfig.Module, clustertypes.RKE1) &&
+		(strings.Contains(terraformConfig.Module, defaults.Custom) || strings.Contains(terraformConfig.Module, defaults.Airgap))) {
+		return defaults.Cluster + "." + terraformConfig.ResourcePrefix + "." + id
+	}
+
+	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		return clusterSync + "." + terraformConfig.ResourcePrefix + "." + defaults.RancherClusterID
+	}
+
+	return defaults.ClusterV2 + "." + terraformConfig.ResourcePrefix + "." + clusterV1ID
+}
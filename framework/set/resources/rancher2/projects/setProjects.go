@@ -0,0 +1,157 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	namespace = "rancher2_namespace"
+	project   = "rancher2_project"
+
+	containerResourceLimit = "container_resource_limit"
+	limit                  = "limit"
+	namespaceDefaultLimit  = "namespace_default_limit"
+	projectID              = "project_id"
+	projectLimit           = "project_limit"
+	resourceQuota          = "resource_quota"
+
+	configMaps             = "config_maps"
+	limitsCPU              = "limits_cpu"
+	limitsMemory           = "limits_memory"
+	persistentVolumeClaims = "persistent_volume_claims"
+	pods                   = "pods"
+	replicationControllers = "replication_controllers"
+	requestsCPU            = "requests_cpu"
+	requestsMemory         = "requests_memory"
+	requestsStorage        = "requests_storage"
+	secrets                = "secrets"
+	services               = "services"
+	servicesLoadBalancers  = "services_load_balancers"
+	servicesNodePorts      = "services_node_ports"
+)
+
+// SetProjects is a function that will set the rancher2_project and rancher2_namespace configurations of the cluster in the main.tf
+// file. A project with a resource quota must also set the default quota of its namespaces, as Rancher requires both. Projects are
+// only supported for node driver clusters and hosted clusters provisioned by Rancher.
+func SetProjects(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	if strings.Contains(terraformConfig.Module, defaults.Custom) || strings.Contains(terraformConfig.Module, defaults.Airgap) ||
+		strings.Contains(terraformConfig.Module, defaults.Import) {
+		return fmt.Errorf("Projects are not supported for module: %v", terraformConfig.Module)
+	}
+
+	clusterID := rancher2.ClusterIDExpression(terraformConfig)
+
+	for _, projectConfig := range terraformConfig.Projects {
+		if projectConfig.Name == "" {
+			return fmt.Errorf("Name must be set for every project of cluster: %v", terraformConfig.ResourcePrefix)
+		}
+
+		if (projectConfig.ResourceQuota == nil) != (projectConfig.NamespaceDefaultResourceQuota == nil) {
+			return fmt.Errorf("Resource quota and namespace default resource quota must be set together for project: %v", projectConfig.Name)
+		}
+
+		projectResourceName := terraformConfig.ResourcePrefix + "-" + projectConfig.Name
+
+		projectBlock := rootBody.AppendNewBlock(defaults.Resource, []string{project, projectResourceName})
+		projectBlockBody := projectBlock.Body()
+
+		projectBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(projectConfig.Name))
+		projectBlockBody.SetAttributeRaw(defaults.RancherClusterID, hclwrite.TokensForIdentifier(clusterID))
+
+		if projectConfig.ResourceQuota != nil {
+			resourceQuotaBlock := projectBlockBody.AppendNewBlock(resourceQuota, nil)
+			resourceQuotaBlockBody := resourceQuotaBlock.Body()
+
+			setResourceQuotaLimit(resourceQuotaBlockBody.AppendNewBlock(projectLimit, nil).Body(), projectConfig.ResourceQuota)
+			setResourceQuotaLimit(resourceQuotaBlockBody.AppendNewBlock(namespaceDefaultLimit, nil).Body(), projectConfig.NamespaceDefaultResourceQuota)
+		}
+
+		if projectConfig.ContainerResourceLimit != nil {
+			setContainerResourceLimit(projectBlockBody, projectConfig.ContainerResourceLimit)
+		}
+
+		rootBody.AppendNewline()
+
+		for _, namespaceConfig := range projectConfig.Namespaces {
+			if namespaceConfig.Name == "" {
+				return fmt.Errorf("Name must be set for every namespace of project: %v", projectConfig.Name)
+			}
+
+			namespaceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{namespace, terraformConfig.ResourcePrefix + "-" + namespaceConfig.Name})
+			namespaceBlockBody := namespaceBlock.Body()
+
+			namespaceBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(namespaceConfig.Name))
+			namespaceBlockBody.SetAttributeRaw(projectID, hclwrite.TokensForIdentifier(project+"."+projectResourceName+".id"))
+
+			if namespaceConfig.ResourceQuota != nil {
+				resourceQuotaBlock := namespaceBlockBody.AppendNewBlock(resourceQuota, nil)
+				setResourceQuotaLimit(resourceQuotaBlock.Body().AppendNewBlock(limit, nil).Body(), namespaceConfig.ResourceQuota)
+			}
+
+			if namespaceConfig.ContainerResourceLimit != nil {
+				setContainerResourceLimit(namespaceBlockBody, namespaceConfig.ContainerResourceLimit)
+			}
+
+			rootBody.AppendNewline()
+		}
+	}
+
+	return nil
+}
+
+// setResourceQuotaLimit is a helper function that will set the limits of a resource quota that are set in the config.
+func setResourceQuotaLimit(limitBlockBody *hclwrite.Body, resourceQuotaLimit *management.ResourceQuotaLimit) {
+	limits := []struct {
+		name  string
+		value string
+	}{
+		{configMaps, resourceQuotaLimit.ConfigMaps},
+		{limitsCPU, resourceQuotaLimit.LimitsCPU},
+		{limitsMemory, resourceQuotaLimit.LimitsMemory},
+		{persistentVolumeClaims, resourceQuotaLimit.PersistentVolumeClaims},
+		{pods, resourceQuotaLimit.Pods},
+		{replicationControllers, resourceQuotaLimit.ReplicationControllers},
+		{requestsCPU, resourceQuotaLimit.RequestsCPU},
+		{requestsMemory, resourceQuotaLimit.RequestsMemory},
+		{requestsStorage, resourceQuotaLimit.RequestsStorage},
+		{secrets, resourceQuotaLimit.Secrets},
+		{services, resourceQuotaLimit.Services},
+		{servicesLoadBalancers, resourceQuotaLimit.ServicesLoadBalancers},
+		{servicesNodePorts, resourceQuotaLimit.ServicesNodePorts},
+	}
+
+	for _, quota := range limits {
+		if quota.value != "" {
+			limitBlockBody.SetAttributeValue(quota.name, cty.StringVal(quota.value))
+		}
+	}
+}
+
+// setContainerResourceLimit is a helper function that will set the default resource limits of containers that are set in the config.
+func setContainerResourceLimit(blockBody *hclwrite.Body, containerLimit *management.ContainerResourceLimit) {
+	containerResourceLimitBlockBody := blockBody.AppendNewBlock(containerResourceLimit, nil).Body()
+
+	limits := []struct {
+		name  string
+		value string
+	}{
+		{limitsCPU, containerLimit.LimitsCPU},
+		{limitsMemory, containerLimit.LimitsMemory},
+		{requestsCPU, containerLimit.RequestsCPU},
+		{requestsMemory, containerLimit.RequestsMemory},
+	}
+
+	for _, resourceLimit := range limits {
+		if resourceLimit.value != "" {
+			containerResourceLimitBlockBody.SetAttributeValue(resourceLimit.name, cty.StringVal(resourceLimit.value))
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
@@ -11,6 +12,7 @@ import (
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -24,7 +26,9 @@ const (
 	ec2                     = "ec2"
 	globalRoleBinding       = "rancher2_global_role_binding"
 	globalRoleID            = "global_role_id"
+	harvesterKubeconfig     = "local.yaml"
 	insecure                = "insecure"
+	kubeconfig              = "kubeconfig"
 	name                    = "name"
 	password                = "password"
 	provider                = "provider"
@@ -47,6 +51,7 @@ const (
 	providerEnvVar          = "RANCHER2_PROVIDER_VERSION"
 	cloudProviderEnvVar     = "CLOUD_PROVIDER_VERSION"
 	localProviderEnvVar     = "LOCALS_PROVIDER_VERSION"
+	kubeProviderEnvVar      = "KUBERNETES_PROVIDER_VERSION"
 	rkeEnvVar               = "RKE_PROVIDER_VERSION"
 )
 
@@ -87,6 +92,18 @@ func createRequiredProviders(rootBody *hclwrite.Body, configMap []map[string]any
 		}))
 	}
 
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Harvester && customModule {
+		reqProvsBlockBody.SetAttributeValue(defaults.Harvester, cty.ObjectVal(map[string]cty.Value{
+			defaults.Source:  cty.StringVal(defaults.HarvesterSource),
+			defaults.Version: cty.StringVal(cloudProviderVersion),
+		}))
+
+		reqProvsBlockBody.SetAttributeValue(defaults.Kubernetes, cty.ObjectVal(map[string]cty.Value{
+			defaults.Source:  cty.StringVal(defaults.KubernetesSource),
+			defaults.Version: cty.StringVal(os.Getenv(kubeProviderEnvVar)),
+		}))
+	}
+
 	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Linode && customModule {
 		reqProvsBlockBody.SetAttributeValue(defaults.Linode, cty.ObjectVal(map[string]cty.Value{
 			defaults.Source:  cty.StringVal(defaults.LinodeSource),
@@ -138,6 +155,28 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, conf
 		rootBody.AppendNewline()
 	}
 
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Harvester && customModule {
+		kubeconfigPath := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(`"${path.module}/` + harvesterKubeconfig + `"`)},
+		}
+
+		harvesterProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Harvester})
+		harvesterProvBlockBody := harvesterProvBlock.Body()
+
+		harvesterProvBlockBody.SetAttributeRaw(kubeconfig, kubeconfigPath)
+
+		rootBody.AppendNewline()
+
+		kubernetesProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Kubernetes})
+		kubernetesProvBlockBody := kubernetesProvBlock.Body()
+
+		kubernetesProvBlockBody.SetAttributeRaw(defaults.ConfigPath, kubeconfigPath)
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
+		rootBody.AppendNewline()
+	}
+
 	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Linode && customModule {
 		linodeProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Linode})
 		linodeProvBlockBody := linodeProvBlock.Body()
@@ -222,8 +261,9 @@ func getRequiredProviderVersions(configMap []map[string]any) (source, rancherPro
 			}
 		}
 
-		if strings.Contains(module, defaults.Custom) || strings.Contains(module, defaults.Import) || strings.Contains(module, defaults.Airgap) ||
-			strings.Contains(module, ec2) {
+		if (strings.Contains(module, defaults.Custom) || strings.Contains(module, defaults.Import) || strings.Contains(module, defaults.Airgap) ||
+			strings.Contains(module, ec2)) && terraformConfig.Provider != defaults.BYO && module != modules.ImportKubeconfig &&
+			!isHostedImport(module) {
 			cloudProviderVersion = os.Getenv(cloudProviderEnvVar)
 			if cloudProviderVersion == "" {
 				logrus.Fatalf("Expected env var not set %s", cloudProviderEnvVar)
@@ -238,3 +278,8 @@ func getRequiredProviderVersions(configMap []map[string]any) (source, rancherPro
 
 	return source, rancherProviderVersion, cloudProviderVersion, localProviderVersion, rkeProviderVersion
 }
+
+// isHostedImport returns true if the module imports an existing hosted cluster, which does not need any cloud resources.
+func isHostedImport(module string) bool {
+	return module == modules.ImportAKS || module == modules.ImportEKS || module == modules.ImportGKE
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/format"
 )
 
 // SetResourceNodepoolValidation is a function that will validate the nodepool configurations.
@@ -18,13 +19,17 @@ func SetResourceNodepoolValidation(terraformConfig *config.TerraformConfig, pool
 			return false, fmt.Errorf(`Invalid quantity specified for pool %v. Quantity must be greater than 0.`, poolNum)
 		}
 
-		return true, nil
+		if module == clustertypes.AKS && pool.Spot && poolNum == "0" {
+			return false, fmt.Errorf(`Spot instances are not supported for AKS pool %v. The first pool is the system pool of the cluster.`, poolNum)
+		}
+
+		return validateHostedNodepool(pool, poolNum)
 	case module == clustertypes.EKS:
 		if pool.DesiredSize <= 0 {
 			return false, fmt.Errorf(`Invalid desired size specified for pool %v. Desired size must be greater than 0.`, poolNum)
 		}
 
-		return true, nil
+		return validateHostedNodepool(pool, poolNum)
 	case strings.Contains(module, clustertypes.RKE1) || strings.Contains(module, clustertypes.RKE2) || strings.Contains(module, clustertypes.K3S):
 		if !pool.Etcd && !pool.Controlplane && !pool.Worker {
 			return false, fmt.Errorf(`No roles selected for pool %v. At least one role is required`, poolNum)
@@ -39,3 +44,19 @@ func SetResourceNodepoolValidation(terraformConfig *config.TerraformConfig, pool
 		return false, fmt.Errorf("Unsupported module: %v", module)
 	}
 }
+
+// validateHostedNodepool is a function that will validate the autoscaling and taint configurations of a hosted nodepool.
+func validateHostedNodepool(pool config.Nodepool, poolNum string) (bool, error) {
+	if pool.MaxSize > 0 && pool.MinSize > pool.MaxSize {
+		return false, fmt.Errorf(`Invalid autoscaling specified for pool %v. Min size must not be greater than max size.`, poolNum)
+	}
+
+	for _, taint := range pool.Taints {
+		_, err := format.ParseTaint(taint)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
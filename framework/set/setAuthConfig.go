@@ -10,7 +10,9 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/authproviders/ad"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/azureAD"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/github"
+	"github.com/rancher/tfp-automation/framework/set/authproviders/keycloak"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/ldap"
+	"github.com/rancher/tfp-automation/framework/set/authproviders/oidc"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/okta"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 
@@ -33,9 +35,18 @@ func AuthConfig(rancherConfig *rancher.Config, testUser, testPassword string, co
 	case authproviders.AzureAD:
 		err = azureAD.SetAzureAD(rancherConfig, terraform, newFile, rootBody, file)
 		return err
+	case authproviders.GenericOIDC:
+		err = oidc.SetGenericOIDC(rancherConfig, terraform.GenericOIDCConfig, newFile, rootBody, file)
+		return err
 	case authproviders.GitHub:
 		err = github.SetGithub(terraform, newFile, rootBody, file)
 		return err
+	case authproviders.Keycloak:
+		err = keycloak.SetKeycloak(rancherConfig, terraform, newFile, rootBody, file)
+		return err
+	case authproviders.KeycloakOIDC:
+		err = oidc.SetKeycloakOIDC(rancherConfig, terraform.KeycloakOIDCConfig, newFile, rootBody, file)
+		return err
 	case authproviders.Okta:
 		err = okta.SetOkta(rancherConfig, terraform, newFile, rootBody, file)
 		return err
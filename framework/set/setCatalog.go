@@ -0,0 +1,22 @@
+package set
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/chartrepo"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2/catalogs"
+)
+
+// Catalog is a function that will set the main.tf file with the catalog of the standalone chart repository of the given repo type.
+func Catalog(rancherConfig *rancher.Config, testUser, testPassword string, configMap []map[string]any, server *chartrepo.Server,
+	repoType string, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) error {
+	newFile, rootBody = resources.SetProvidersAndUsersTF(rancherConfig, testUser, testPassword, false, newFile, rootBody, configMap, false)
+
+	_, terraform, _, _ := config.LoadTFPConfigs(configMap[0])
+
+	return catalogs.SetCatalog(terraform, server, repoType, newFile, rootBody, file)
+}
@@ -1,6 +1,7 @@
 package set
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2/apps"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2/projects"
 	"github.com/sirupsen/logrus"
 )
 
@@ -44,6 +47,12 @@ func ConfigTF(client *rancher.Client, rancherConfig *rancher.Config, terratestCo
 
 		clusterNames = append(clusterNames, terraformConfig.ResourcePrefix)
 
+		// The hardened node user-data is set through the machine configs and node templates of node driver clusters.
+		if terraformConfig.Hardened && (strings.Contains(terraformConfig.Module, defaults.Custom) || strings.Contains(terraformConfig.Module, defaults.Airgap) ||
+			strings.Contains(terraformConfig.Module, defaults.Import)) {
+			return clusterNames, nil, fmt.Errorf("Hardened clusters are not supported for module: %v", terraformConfig.Module)
+		}
+
 		if (strings.Contains(terraformConfig.Module, defaults.Custom) || strings.Contains(terraformConfig.Module, defaults.Airgap)) && !strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
 			customClusterNames = append(customClusterNames, terraformConfig.ResourcePrefix)
 		}
@@ -83,6 +92,20 @@ func ConfigTF(client *rancher.Client, rancherConfig *rancher.Config, terratestCo
 			}
 		}
 
+		if len(terraformConfig.Projects) > 0 {
+			err = projects.SetProjects(rootBody, terraformConfig)
+			if err != nil {
+				return clusterNames, nil, err
+			}
+		}
+
+		if len(terraformConfig.Apps) > 0 {
+			err = apps.SetApps(rootBody, terraformConfig)
+			if err != nil {
+				return clusterNames, nil, err
+			}
+		}
+
 		if i == len(configMap)-1 && containsCustomModule {
 			localsBlock := newFile.Body().FirstMatchingBlock(defaults.Locals, nil)
 			if localsBlock != nil {
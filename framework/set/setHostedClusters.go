@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
 )
 
@@ -15,6 +16,15 @@ func HostedClusters(terraformConfig *config.TerraformConfig, terratestConfig *co
 	rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
 	var err error
 
+	switch terraformConfig.Module {
+	case modules.ImportAKS:
+		return hosted.SetImportedAKS(terraformConfig, newFile, rootBody, file)
+	case modules.ImportEKS:
+		return hosted.SetImportedEKS(terraformConfig, newFile, rootBody, file)
+	case modules.ImportGKE:
+		return hosted.SetImportedGKE(terraformConfig, newFile, rootBody, file)
+	}
+
 	if strings.Contains(terraformConfig.Module, clustertypes.AKS) {
 		newFile, file, err = hosted.SetAKS(terraformConfig, terratestConfig, newFile, rootBody, file)
 		if err != nil {
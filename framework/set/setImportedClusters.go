@@ -8,6 +8,8 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/kubeconfig"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/rke1"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/rke2k3s"
 )
@@ -18,6 +20,10 @@ func ImportedClusters(client *rancher.Client, terraformConfig *config.TerraformC
 	isWindows bool) (*hclwrite.File, *os.File, error) {
 	var err error
 
+	if terraformConfig.Module == modules.ImportKubeconfig {
+		return kubeconfig.SetImportedKubeconfig(terraformConfig, newFile, rootBody, file)
+	}
+
 	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
 		newFile, file, err = rke1.SetImportedRKE1(terraformConfig, terratestConfig, newFile, rootBody, file)
 		if err != nil {
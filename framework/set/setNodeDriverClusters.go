@@ -8,9 +8,11 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	configuration "github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke1"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
+	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/rbac"
 )
 
@@ -47,5 +49,9 @@ func NodeDriverClusters(client *rancher.Client, terraformConfig *config.Terrafor
 		}
 	}
 
+	if terraformConfig.CloudProvider == cloudproviders.AWS {
+		aws.SetEBSCSIDriver(rootBody, terraformConfig)
+	}
+
 	return newFile, file, nil
 }
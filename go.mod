@@ -49,6 +49,7 @@ require (
 
 require (
 	github.com/antihax/optional v1.0.0
+	github.com/aws/aws-sdk-go v1.55.6
 	github.com/gruntwork-io/terratest v0.49.0
 	github.com/imdario/mergo v0.3.16
 	github.com/rancher/norman v0.7.0
@@ -89,7 +90,6 @@ require (
 
 require (
 	github.com/agext/levenshtein v1.2.3 // indirect
-	github.com/aws/aws-sdk-go v1.55.6 // indirect
 	github.com/bgentry/go-netrc v0.0.0-20140422174119-9fd32a8b3d3d // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
@@ -115,7 +115,7 @@ require (
 	github.com/zclconf/go-cty v1.15.0
 	golang.org/x/crypto v0.40.0
 	golang.org/x/net v0.42.0 // indirect
-	golang.org/x/oauth2 v0.30.0 // indirect
+	golang.org/x/oauth2 v0.30.0
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
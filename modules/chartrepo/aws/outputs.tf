@@ -0,0 +1,3 @@
+output "chartrepo_public_ip" {
+  value = aws_instance.chartrepo.public_ip
+}
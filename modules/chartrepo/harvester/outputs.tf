@@ -0,0 +1,3 @@
+output "chartrepo_public_ip" {
+  value = harvester_virtualmachine.chartrepo.network_interface[0].ip_address
+}
@@ -0,0 +1,3 @@
+output "chartrepo_public_ip" {
+  value = linode_instance.chartrepo.ip_address
+}
@@ -0,0 +1,3 @@
+output "chartrepo_public_ip" {
+  value = vsphere_virtual_machine.chartrepo.default_ip_address
+}
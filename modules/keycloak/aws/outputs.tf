@@ -0,0 +1,3 @@
+output "keycloak_public_ip" {
+  value = aws_instance.keycloak.public_ip
+}
@@ -0,0 +1,3 @@
+output "keycloak_public_ip" {
+  value = harvester_virtualmachine.keycloak.network_interface[0].ip_address
+}
@@ -0,0 +1,3 @@
+output "keycloak_public_ip" {
+  value = linode_instance.keycloak.ip_address
+}
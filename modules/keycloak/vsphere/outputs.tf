@@ -0,0 +1,3 @@
+output "keycloak_public_ip" {
+  value = vsphere_virtual_machine.keycloak.default_ip_address
+}
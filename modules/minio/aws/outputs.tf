@@ -0,0 +1,3 @@
+output "minio_public_ip" {
+  value = aws_instance.minio.public_ip
+}
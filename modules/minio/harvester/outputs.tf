@@ -0,0 +1,3 @@
+output "minio_public_ip" {
+  value = harvester_virtualmachine.minio.network_interface[0].ip_address
+}
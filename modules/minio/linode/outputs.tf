@@ -0,0 +1,3 @@
+output "minio_public_ip" {
+  value = linode_instance.minio.ip_address
+}
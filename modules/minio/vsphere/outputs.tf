@@ -0,0 +1,3 @@
+output "minio_public_ip" {
+  value = vsphere_virtual_machine.minio.default_ip_address
+}
@@ -7,13 +7,17 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 	upstream "go.qase.io/qase-api-client"
 )
 
 // GetProvisioningSchemaParams gets a set of params from the cattle config and returns a qase params object
 func GetProvisioningSchemaParams(configMap map[string]any) []upstream.TestCaseParameterCreate {
 	var params []upstream.TestCaseParameterCreate
-	var rancherType, upgradedRancherType, amiParam, windows2019AMIParam, windows2022AMIParam upstream.TestCaseParameterCreate
+	var rancherType, upgradedRancherType, amiParam, doImageParam, windowsParam upstream.TestCaseParameterCreate
 
 	_, terraform, terratest, _ := config.LoadTFPConfigs(configMap)
 
@@ -39,12 +43,18 @@ func GetProvisioningSchemaParams(configMap map[string]any) []upstream.TestCasePa
 		amiParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "AMI", Values: []string{terraform.AWSConfig.AMI}}}
 	}
 
-	if strings.Contains(terraform.Module, clustertypes.WINDOWS) && strings.Contains(terraform.Module, "2019") {
-		windows2019AMIParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2019AMI", Values: []string{terraform.AWSConfig.Windows2019AMI}}}
+	if strings.HasPrefix(terraform.Module, modules.DO) {
+		doImageParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "DigitalOceanImage", Values: []string{terraform.DigitalOceanConfig.Image}}}
 	}
 
-	if strings.Contains(terraform.Module, clustertypes.WINDOWS) && strings.Contains(terraform.Module, "2022") {
-		windows2022AMIParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2022AMI", Values: []string{terraform.AWSConfig.Windows2022AMI}}}
+	if strings.Contains(terraform.Module, clustertypes.WINDOWS) {
+		windowsVersion, _ := format.WindowsVersion(terraform.Module, terratest.WindowsOSVersion)
+
+		if terraform.Provider == defaults.Vsphere {
+			windowsParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows" + windowsVersion + "Template", Values: []string{vsphere.WindowsTemplate(terraform, windowsVersion)}}}
+		} else {
+			windowsParam = upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows" + windowsVersion + "AMI", Values: []string{aws.WindowsAMI(terraform, windowsVersion)}}}
+		}
 	}
 
 	moduleParam := upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Module", Values: []string{terraform.Module}}}
@@ -52,7 +62,7 @@ func GetProvisioningSchemaParams(configMap map[string]any) []upstream.TestCasePa
 	cniParam := upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "CNI", Values: []string{terraform.CNI}}}
 	timeParam := upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Time", Values: []string{currentDate}}}
 
-	params = append(params, rancherType, upgradedRancherType, amiParam, windows2019AMIParam, windows2022AMIParam, moduleParam, k8sParam, cniParam, timeParam)
+	params = append(params, rancherType, upgradedRancherType, amiParam, doImageParam, windowsParam, moduleParam, k8sParam, cniParam, timeParam)
 
 	return params
 }
@@ -75,6 +75,7 @@ func (a *TfpAirgapProvisioningTestSuite) TestTfpAirgapProvisioning() {
 		{"Airgap_RKE2", modules.AirgapRKE2},
 		{"Airgap_RKE2_Windows_2019", modules.AirgapRKE2Windows2019},
 		{"Airgap_RKE2_Windows_2022", modules.AirgapRKE2Windows2022},
+		{"Airgap_RKE2_Windows_2025", modules.AirgapRKE2Windows2025},
 		{"Airgap_K3S", modules.AirgapK3S},
 	}
 
@@ -102,6 +102,7 @@ func (a *TfpAirgapUpgradeRancherTestSuite) provisionAndVerifyCluster(name string
 		{"RKE2", modules.AirgapRKE2},
 		{"RKE2_Windows_2019", modules.AirgapRKE2Windows2019},
 		{"RKE2_Windows_2022", modules.AirgapRKE2Windows2022},
+		{"RKE2_Windows_2025", modules.AirgapRKE2Windows2025},
 		{"K3S", modules.AirgapK3S},
 	}
 
@@ -0,0 +1,298 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	aksActiveState = "Succeeded"
+	eksActiveState = eks.ClusterStatusActive
+	gkeActiveState = "RUNNING"
+
+	azureAPIVersion     = "2024-05-01"
+	azureManagementURL  = "https://management.azure.com"
+	azureTokenURL       = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	azureManagementAuth = azureManagementURL + "/.default"
+	googleContainerURL  = "https://container.googleapis.com/v1"
+	googleCloudAuth     = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// HostedCluster is a hosted cluster as reported by the API of its cloud provider.
+type HostedCluster struct {
+	Name      string
+	State     string
+	NodePools map[string]HostedNodePool
+}
+
+// HostedNodePool is a node pool of a hosted cluster as reported by the API of its cloud provider. The count of a GKE node
+// pool is its initial node count per zone.
+type HostedNodePool struct {
+	Count        int64
+	Version      string
+	InstanceType string
+}
+
+// FindHostedCluster looks up the existing hosted cluster of the given import module, and its node pools, through the API
+// of its cloud provider.
+func FindHostedCluster(terraformConfig *config.TerraformConfig, module string) (*HostedCluster, error) {
+	switch {
+	case strings.Contains(module, clustertypes.AKS):
+		return findAKSCluster(terraformConfig)
+	case strings.Contains(module, clustertypes.EKS):
+		return findEKSCluster(terraformConfig)
+	case strings.Contains(module, clustertypes.GKE):
+		return findGKECluster(terraformConfig)
+	default:
+		return nil, fmt.Errorf("Unsupported module: %v", module)
+	}
+}
+
+// VerifyHostedNodePoolsUnchanged validates, through the API of the cloud provider, that the node pools of the hosted cluster
+// still have the count, version and instance type they had before the cluster was imported into Rancher.
+func VerifyHostedNodePoolsUnchanged(t *testing.T, terraformConfig *config.TerraformConfig, module string, expectedCluster *HostedCluster) {
+	hostedCluster, err := FindHostedCluster(terraformConfig, module)
+	require.NoError(t, err)
+
+	for poolName, expectedPool := range expectedCluster.NodePools {
+		pool, ok := hostedCluster.NodePools[poolName]
+		require.Truef(t, ok, "node pool %s was removed from the cloud provider", poolName)
+
+		logrus.Infof("Node pool %s has %v nodes of %s on version %s", poolName, pool.Count, pool.InstanceType, pool.Version)
+		require.Equalf(t, expectedPool, pool, "node pool %s was modified by the import", poolName)
+	}
+}
+
+// VerifyHostedClusterIntact validates, through the API of the cloud provider, that the hosted cluster and the node pools
+// Rancher discovered are left running once the imported cluster is removed from Rancher.
+func VerifyHostedClusterIntact(t *testing.T, terraformConfig *config.TerraformConfig, module string, discoveredPools map[string]string) {
+	hostedCluster, err := FindHostedCluster(terraformConfig, module)
+	require.NoError(t, err)
+
+	var activeState string
+	switch {
+	case strings.Contains(module, clustertypes.AKS):
+		activeState = aksActiveState
+	case strings.Contains(module, clustertypes.EKS):
+		activeState = eksActiveState
+	case strings.Contains(module, clustertypes.GKE):
+		activeState = gkeActiveState
+	}
+
+	logrus.Infof("Cluster %s is %s with node pools %v", hostedCluster.Name, hostedCluster.State, hostedCluster.NodePools)
+	require.Equal(t, activeState, hostedCluster.State)
+
+	for poolName := range discoveredPools {
+		require.Containsf(t, hostedCluster.NodePools, poolName, "node pool %s was removed from the cloud provider", poolName)
+	}
+}
+
+// findEKSCluster looks up the imported EKS cluster and its node groups with the AWS SDK.
+func findEKSCluster(terraformConfig *config.TerraformConfig) (*HostedCluster, error) {
+	awsSession, err := session.NewSession(&aws.Config{
+		Region: aws.String(terraformConfig.AWSConfig.Region),
+		Credentials: credentials.NewStaticCredentials(terraformConfig.AWSCredentials.AWSAccessKey,
+			terraformConfig.AWSCredentials.AWSSecretKey, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eksClient := eks.New(awsSession)
+	clusterName := terraformConfig.HostedImport.EKSClusterName
+
+	cluster, err := eksClient.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return nil, err
+	}
+
+	hostedCluster := &HostedCluster{Name: clusterName, State: aws.StringValue(cluster.Cluster.Status), NodePools: map[string]HostedNodePool{}}
+
+	var nodeGroupNames []string
+	err = eksClient.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)},
+		func(page *eks.ListNodegroupsOutput, lastPage bool) bool {
+			nodeGroupNames = append(nodeGroupNames, aws.StringValueSlice(page.Nodegroups)...)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeGroupName := range nodeGroupNames {
+		nodeGroup, err := eksClient.DescribeNodegroup(&eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String(nodeGroupName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var desiredSize int64
+		if nodeGroup.Nodegroup.ScalingConfig != nil {
+			desiredSize = aws.Int64Value(nodeGroup.Nodegroup.ScalingConfig.DesiredSize)
+		}
+
+		hostedCluster.NodePools[nodeGroupName] = HostedNodePool{
+			Count:        desiredSize,
+			Version:      aws.StringValue(nodeGroup.Nodegroup.Version),
+			InstanceType: strings.Join(aws.StringValueSlice(nodeGroup.Nodegroup.InstanceTypes), ","),
+		}
+	}
+
+	return hostedCluster, nil
+}
+
+// findAKSCluster looks up the imported AKS cluster and its agent pools with the Azure Resource Manager API.
+func findAKSCluster(terraformConfig *config.TerraformConfig) (*HostedCluster, error) {
+	credentialsConfig := clientcredentials.Config{
+		ClientID:     terraformConfig.AzureCredentials.ClientID,
+		ClientSecret: terraformConfig.AzureCredentials.ClientSecret,
+		TokenURL:     fmt.Sprintf(azureTokenURL, terraformConfig.AzureCredentials.TenantID),
+		Scopes:       []string{azureManagementAuth},
+	}
+
+	clusterName := terraformConfig.HostedImport.AKSClusterName
+	clusterURL := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s?api-version=%s",
+		azureManagementURL, terraformConfig.AzureCredentials.SubscriptionID, terraformConfig.AzureConfig.ResourceGroup, clusterName, azureAPIVersion)
+
+	cluster := struct {
+		Properties struct {
+			ProvisioningState string `json:"provisioningState"`
+			AgentPoolProfiles []struct {
+				Name                string `json:"name"`
+				Count               int64  `json:"count"`
+				OrchestratorVersion string `json:"orchestratorVersion"`
+				VMSize              string `json:"vmSize"`
+			} `json:"agentPoolProfiles"`
+		} `json:"properties"`
+	}{}
+
+	err := getCloudResource(credentialsConfig.Client(context.TODO()), clusterURL, &cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	hostedCluster := &HostedCluster{Name: clusterName, State: cluster.Properties.ProvisioningState, NodePools: map[string]HostedNodePool{}}
+	for _, pool := range cluster.Properties.AgentPoolProfiles {
+		hostedCluster.NodePools[pool.Name] = HostedNodePool{Count: pool.Count, Version: pool.OrchestratorVersion, InstanceType: pool.VMSize}
+	}
+
+	return hostedCluster, nil
+}
+
+// gkeCluster is a GKE cluster as reported by the Google Kubernetes Engine API.
+type gkeCluster struct {
+	Status    string   `json:"status"`
+	Locations []string `json:"locations"`
+	NodePools []struct {
+		Name             string   `json:"name"`
+		Locations        []string `json:"locations"`
+		InitialNodeCount int64    `json:"initialNodeCount"`
+		Version          string   `json:"version"`
+		Config           struct {
+			MachineType string `json:"machineType"`
+		} `json:"config"`
+	} `json:"nodePools"`
+}
+
+// findGKECluster looks up the imported GKE cluster and its node pools with the Google Kubernetes Engine API.
+func findGKECluster(terraformConfig *config.TerraformConfig) (*HostedCluster, error) {
+	clusterName := terraformConfig.HostedImport.GKEClusterName
+
+	cluster, err := getGKECluster(terraformConfig, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	hostedCluster := &HostedCluster{Name: clusterName, State: cluster.Status, NodePools: map[string]HostedNodePool{}}
+	for _, pool := range cluster.NodePools {
+		hostedCluster.NodePools[pool.Name] = HostedNodePool{Count: pool.InitialNodeCount, Version: pool.Version, InstanceType: pool.Config.MachineType}
+	}
+
+	return hostedCluster, nil
+}
+
+// gkeNodePoolZones returns the number of zones each node pool of a GKE cluster runs in. The node count of a GKE node pool
+// is per zone, so a pool of a regional cluster runs its node count in each of its zones.
+func gkeNodePoolZones(terraformConfig *config.TerraformConfig, clusterName string) (map[string]int64, error) {
+	cluster, err := getGKECluster(terraformConfig, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	poolZones := map[string]int64{}
+	for _, pool := range cluster.NodePools {
+		locations := pool.Locations
+		if len(locations) == 0 {
+			locations = cluster.Locations
+		}
+
+		poolZones[pool.Name] = int64(len(locations))
+	}
+
+	return poolZones, nil
+}
+
+// getGKECluster gets a GKE cluster of the configured region with the Google Kubernetes Engine API, authenticating as the
+// service account of the Google credentials.
+func getGKECluster(terraformConfig *config.TerraformConfig, clusterName string) (*gkeCluster, error) {
+	serviceAccount := struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}{}
+
+	err := json.Unmarshal([]byte(terraformConfig.GoogleCredentials.AuthEncodedJSON), &serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtConfig := jwt.Config{
+		Email:      serviceAccount.ClientEmail,
+		PrivateKey: []byte(serviceAccount.PrivateKey),
+		TokenURL:   serviceAccount.TokenURI,
+		Scopes:     []string{googleCloudAuth},
+	}
+
+	clusterURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters/%s", googleContainerURL, terraformConfig.GoogleConfig.ProjectID,
+		terraformConfig.GoogleConfig.Region, clusterName)
+
+	cluster := &gkeCluster{}
+
+	err = getCloudResource(jwtConfig.Client(context.TODO()), clusterURL, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// getCloudResource gets the resource at the given URL of a cloud provider API and decodes it into output.
+func getCloudResource(httpClient *http.Client, resourceURL string, output any) error {
+	resp, err := httpClient.Get(resourceURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to get %v: %v", resourceURL, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(output)
+}
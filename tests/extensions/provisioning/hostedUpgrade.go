@@ -0,0 +1,161 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
+)
+
+// Messages returned by AKS, EKS and GKE, or their Rancher operators, when the version of a node pool is not compatible
+// with the version of the control plane.
+var versionSkewMessages = []string{
+	"nodepoolmcversionincompatible",
+	"are incompatible",
+	"greater minor version than master",
+	"cannot be greater than",
+	"should be equal to the kubernetes version of the cluster",
+	"version skew",
+}
+
+// VersionSkewError is returned when a hosted upgrade is rejected because the node pool and control plane versions are too far apart.
+type VersionSkewError struct {
+	Module              string
+	ControlPlaneVersion string
+	NodePoolVersion     string
+	Err                 error
+}
+
+func (e *VersionSkewError) Error() string {
+	return fmt.Sprintf("%s rejected node pool version %s with control plane version %s: %v", e.Module, e.NodePoolVersion,
+		e.ControlPlaneVersion, e.Err)
+}
+
+func (e *VersionSkewError) Unwrap() error {
+	return e.Err
+}
+
+// HostedControlPlaneUpgrade is a function that will run terraform apply and upgrade only the control plane of a hosted
+// cluster to the upgraded Kubernetes version. Every node pool is pinned to the version it is currently running.
+func HostedControlPlaneUpgrade(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, testUser, testPassword string,
+	terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) error {
+	_, terraformConfig, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+	if terratest.UpgradedKubernetesVersion == "" {
+		return fmt.Errorf("upgradedKubernetesVersion must be set for module: %v", terraformConfig.Module)
+	}
+
+	nodePools := terratest.Nodepools
+	for i := range nodePools {
+		if nodePools[i].KubernetesVersion == "" {
+			nodePools[i].KubernetesVersion = terratest.KubernetesVersion
+		}
+	}
+
+	_, err := operations.ReplaceValue([]string{"terratest", "nodepools"}, nodePools, configMap[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, terratest.UpgradedKubernetesVersion, configMap[0])
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Upgrading the control plane of %s to %s...", terraformConfig.ResourcePrefix, terratest.UpgradedKubernetesVersion)
+
+	return applyHostedUpgrade(t, client, rancherConfig, terratest, testUser, testPassword, terraformOptions, configMap, newFile,
+		rootBody, file, terratest.KubernetesVersion)
+}
+
+// HostedNodePoolUpgrade is a function that will run terraform apply and upgrade the node pool at the given index to the
+// Kubernetes version of the control plane.
+func HostedNodePoolUpgrade(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, testUser, testPassword string,
+	terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File,
+	poolIndex int) error {
+	_, _, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+	return HostedNodePoolVersionUpgrade(t, client, rancherConfig, testUser, testPassword, terraformOptions, configMap, newFile, rootBody,
+		file, poolIndex, terratest.KubernetesVersion)
+}
+
+// HostedNodePoolVersionUpgrade is a function that will run terraform apply and upgrade the node pool at the given index to the
+// given Kubernetes version. If the cloud rejects the upgrade for the version skew with the control plane, a VersionSkewError is
+// returned and the node pool is set back to its previous version in the config map.
+func HostedNodePoolVersionUpgrade(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, testUser, testPassword string,
+	terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File,
+	poolIndex int, kubernetesVersion string) error {
+	_, terraformConfig, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+	if poolIndex >= len(terratest.Nodepools) {
+		return fmt.Errorf("Invalid node pool %v for module: %v", poolIndex, terraformConfig.Module)
+	}
+
+	nodePools := terratest.Nodepools
+	previousVersion := nodePools[poolIndex].KubernetesVersion
+	nodePools[poolIndex].KubernetesVersion = kubernetesVersion
+
+	_, err := operations.ReplaceValue([]string{"terratest", "nodepools"}, nodePools, configMap[0])
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Upgrading node pool %v of %s to %s...", poolIndex, terraformConfig.ResourcePrefix, kubernetesVersion)
+
+	err = applyHostedUpgrade(t, client, rancherConfig, terratest, testUser, testPassword, terraformOptions, configMap, newFile,
+		rootBody, file, kubernetesVersion)
+
+	var versionSkewError *VersionSkewError
+	if errors.As(err, &versionSkewError) {
+		nodePools[poolIndex].KubernetesVersion = previousVersion
+
+		_, replaceErr := operations.ReplaceValue([]string{"terratest", "nodepools"}, nodePools, configMap[0])
+		if replaceErr != nil {
+			return replaceErr
+		}
+	}
+
+	return err
+}
+
+// applyHostedUpgrade sets the main.tf file from the config map and applies it, converting version skew failures reported by the
+// cloud into a VersionSkewError.
+func applyHostedUpgrade(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terratestConfig *config.TerratestConfig,
+	testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File, nodePoolVersion string) error {
+	_, _, err := framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file,
+		false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = terraform.ApplyE(t, terraformOptions)
+	if err == nil {
+		return nil
+	}
+
+	errorMessage := strings.ToLower(err.Error())
+	for _, message := range versionSkewMessages {
+		if strings.Contains(errorMessage, message) {
+			_, terraformConfig, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+			return &VersionSkewError{
+				Module:              terraformConfig.Module,
+				ControlPlaneVersion: terratest.KubernetesVersion,
+				NodePoolVersion:     nodePoolVersion,
+				Err:                 err,
+			}
+		}
+	}
+
+	return err
+}
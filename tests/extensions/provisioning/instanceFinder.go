@@ -0,0 +1,130 @@
+package provisioning
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+)
+
+const nameTag = "tag:Name"
+
+// Instances in these states are still running, or can be started again, so they are orphaned once their cluster is destroyed.
+var orphanedInstanceStates = []string{
+	ec2.InstanceStateNamePending,
+	ec2.InstanceStateNameRunning,
+	ec2.InstanceStateNameStopping,
+	ec2.InstanceStateNameStopped,
+}
+
+// Instance is a provider instance that was created for a cluster.
+type Instance struct {
+	ID    string
+	Name  string
+	State string
+}
+
+func (i Instance) String() string {
+	return fmt.Sprintf("%s (%s, %s)", i.Name, i.ID, i.State)
+}
+
+// InstanceFinder finds the provider instances whose name starts with the given prefix.
+type InstanceFinder interface {
+	FindInstances(namePrefix string) ([]Instance, error)
+}
+
+// NewInstanceFinder returns the InstanceFinder of the provider the cluster was created with. The nodes of hosted clusters
+// are not named after the resource prefix, so hosted modules are not supported.
+func NewInstanceFinder(terraformConfig *config.TerraformConfig) (InstanceFinder, error) {
+	for _, hostedType := range []string{clustertypes.AKS, clustertypes.EKS, clustertypes.GKE} {
+		if strings.Contains(terraformConfig.Module, hostedType) {
+			return nil, fmt.Errorf("Unsupported module for orphaned instance detection: %v", terraformConfig.Module)
+		}
+	}
+
+	if terraformConfig.Provider == defaults.Aws || strings.HasPrefix(terraformConfig.Module, modules.EC2) {
+		awsSession, err := session.NewSession(&aws.Config{
+			Region: aws.String(terraformConfig.AWSConfig.Region),
+			Credentials: credentials.NewStaticCredentials(terraformConfig.AWSCredentials.AWSAccessKey,
+				terraformConfig.AWSCredentials.AWSSecretKey, ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &AWSInstanceFinder{EC2: ec2.New(awsSession)}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported provider for orphaned instance detection: %v", terraformConfig.Provider)
+}
+
+// FindRunningInstances returns the instances named after the given resource prefix that are still running, or can be
+// started again. Every instance of a cluster, whether created by Terraform or by Rancher, is named after the resource prefix.
+func FindRunningInstances(finder InstanceFinder, resourcePrefix string) ([]Instance, error) {
+	namePrefix := resourcePrefix + "-"
+
+	instances, err := finder.FindInstances(namePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var running []Instance
+	for _, instance := range instances {
+		if strings.HasPrefix(instance.Name, namePrefix) && slices.Contains(orphanedInstanceStates, instance.State) {
+			running = append(running, instance)
+		}
+	}
+
+	return running, nil
+}
+
+// AWSInstanceFinder finds the EC2 instances whose Name tag starts with a prefix.
+type AWSInstanceFinder struct {
+	EC2 ec2iface.EC2API
+}
+
+// FindInstances returns the EC2 instances whose Name tag starts with the prefix.
+func (f *AWSInstanceFinder) FindInstances(namePrefix string) ([]Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String(nameTag), Values: aws.StringSlice([]string{namePrefix + "*"})},
+		},
+	}
+
+	var instances []Instance
+
+	err := f.EC2.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				var name string
+				for _, tag := range instance.Tags {
+					if aws.StringValue(tag.Key) == "Name" {
+						name = aws.StringValue(tag.Value)
+					}
+				}
+
+				instances = append(instances, Instance{
+					ID:    aws.StringValue(instance.InstanceId),
+					Name:  name,
+					State: aws.StringValue(instance.State.Name),
+				})
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
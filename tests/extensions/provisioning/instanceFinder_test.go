@@ -0,0 +1,66 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubInstanceFinder is an InstanceFinder that returns the instances it was created with.
+type stubInstanceFinder struct {
+	instances []Instance
+}
+
+func (f *stubInstanceFinder) FindInstances(namePrefix string) ([]Instance, error) {
+	return f.instances, nil
+}
+
+type InstanceFinderTestSuite struct {
+	suite.Suite
+}
+
+func (i *InstanceFinderTestSuite) TestFindRunningInstances() {
+	resourcePrefix := "tfp-abc"
+
+	tests := []struct {
+		name      string
+		instances []Instance
+		orphans   []Instance
+	}{
+		{
+			"No_Orphans",
+			[]Instance{
+				{ID: "i-1", Name: "tfp-abc-server1", State: ec2.InstanceStateNameTerminated},
+				{ID: "i-2", Name: "tfp-abc-pool1-xyz12", State: ec2.InstanceStateNameShuttingDown},
+				{ID: "i-3", Name: "tfp-abcd-server1", State: ec2.InstanceStateNameRunning},
+			},
+			nil,
+		},
+		{
+			"Orphans",
+			[]Instance{
+				{ID: "i-1", Name: "tfp-abc-server1", State: ec2.InstanceStateNameRunning},
+				{ID: "i-2", Name: "tfp-abc-pool1-xyz12", State: ec2.InstanceStateNameStopped},
+				{ID: "i-3", Name: "tfp-abcd-server1", State: ec2.InstanceStateNameRunning},
+			},
+			[]Instance{
+				{ID: "i-1", Name: "tfp-abc-server1", State: ec2.InstanceStateNameRunning},
+				{ID: "i-2", Name: "tfp-abc-pool1-xyz12", State: ec2.InstanceStateNameStopped},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		i.Run(tt.name, func() {
+			orphans, err := FindRunningInstances(&stubInstanceFinder{instances: tt.instances}, resourcePrefix)
+			require.NoError(i.T(), err)
+			require.Equal(i.T(), tt.orphans, orphans)
+		})
+	}
+}
+
+func TestInstanceFinderTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceFinderTestSuite))
+}
@@ -30,6 +30,9 @@ func verifyModule(module string) bool {
 		modules.AzureRKE1,
 		modules.AzureRKE2,
 		modules.AzureK3s,
+		modules.DORKE1,
+		modules.DORKE2,
+		modules.DOK3s,
 		modules.EC2RKE1,
 		modules.EC2RKE2,
 		modules.EC2K3s,
@@ -42,24 +45,42 @@ func verifyModule(module string) bool {
 		modules.VsphereRKE1,
 		modules.VsphereRKE2,
 		modules.VsphereK3s,
+		modules.CustomBYORKE2,
+		modules.CustomBYORKE2Windows,
+		modules.CustomBYOK3s,
 		modules.CustomEC2RKE1,
 		modules.CustomEC2RKE2,
 		modules.CustomEC2RKE2Windows2019,
 		modules.CustomEC2RKE2Windows2022,
+		modules.CustomEC2RKE2Windows2025,
 		modules.CustomEC2K3s,
+		modules.CustomHarvesterRKE1,
+		modules.CustomHarvesterRKE2,
+		modules.CustomHarvesterK3s,
+		modules.CustomLinodeRKE1,
+		modules.CustomLinodeRKE2,
+		modules.CustomLinodeK3s,
 		modules.CustomVsphereRKE1,
 		modules.CustomVsphereRKE2,
+		modules.CustomVsphereRKE2Windows2019,
+		modules.CustomVsphereRKE2Windows2022,
+		modules.CustomVsphereRKE2Windows2025,
 		modules.CustomVsphereK3s,
 		modules.AirgapRKE1,
 		modules.AirgapRKE2,
 		modules.AirgapRKE2Windows2019,
 		modules.AirgapRKE2Windows2022,
+		modules.AirgapRKE2Windows2025,
 		modules.AirgapK3S,
 		modules.ImportEC2RKE1,
 		modules.ImportEC2RKE2,
 		modules.ImportEC2RKE2Windows2019,
 		modules.ImportEC2RKE2Windows2022,
 		modules.ImportEC2K3s,
+		modules.ImportAKS,
+		modules.ImportEKS,
+		modules.ImportGKE,
+		modules.ImportKubeconfig,
 		modules.ImportVsphereRKE1,
 		modules.ImportVsphereRKE2,
 		modules.ImportVsphereK3s,
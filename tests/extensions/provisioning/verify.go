@@ -144,14 +144,26 @@ func VerifyNodeCount(t *testing.T, client *rancher.Client, clusterName string, t
 
 	switch module {
 	case clustertypes.AKS:
-		var aksConfig = cluster.AKSConfig
-		require.Equal(t, (*aksConfig.NodePools)[0].Count, cluster.NodeCount)
+		var expectedNodeCount int64
+		for _, pool := range *cluster.AKSConfig.NodePools {
+			expectedNodeCount += *pool.Count
+		}
+
+		require.Equal(t, expectedNodeCount, cluster.NodeCount)
 	case clustertypes.EKS:
-		var eksConfig = cluster.EKSConfig
-		require.Equal(t, (*eksConfig.NodeGroups)[0].DesiredSize, cluster.NodeCount)
+		var expectedNodeCount int64
+		for _, nodeGroup := range *cluster.EKSConfig.NodeGroups {
+			expectedNodeCount += *nodeGroup.DesiredSize
+		}
+
+		require.Equal(t, expectedNodeCount, cluster.NodeCount)
 	case clustertypes.GKE:
-		var gkeConfig = cluster.GKEConfig
-		require.Equal(t, (*gkeConfig.NodePools)[0].InitialNodeCount, cluster.NodeCount)
+		var expectedNodeCount int64
+		for _, pool := range *cluster.GKEConfig.NodePools {
+			expectedNodeCount += *pool.InitialNodeCount
+		}
+
+		require.Equal(t, expectedNodeCount, cluster.NodeCount)
 	case clustertypes.RKE1, clustertypes.RKE2, clustertypes.K3S:
 		require.Equal(t, nodeCount, cluster.NodeCount)
 	default:
@@ -0,0 +1,48 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	catalogv1 "github.com/rancher/rancher/pkg/apis/catalog.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/charts"
+	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VerifyApps validates that every app of the cluster is deployed with the expected chart version and that its workloads are active.
+func VerifyApps(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterID, err := clusterExtensions.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	catalogClient, err := client.GetClusterCatalogClient(clusterID)
+	require.NoError(t, err)
+
+	for _, app := range terraformConfig.Apps {
+		logrus.Infof("Waiting for app %s to be deployed in namespace %s...", app.Name, app.Namespace)
+		err = charts.WaitChartInstall(catalogClient, app.Namespace, app.Name)
+		require.NoError(t, err)
+
+		deployedApp, err := catalogClient.Apps(app.Namespace).Get(context.TODO(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, string(catalogv1.StatusDeployed), deployedApp.Status.Summary.State)
+
+		if app.Version != "" {
+			require.Equal(t, app.Version, deployedApp.Spec.Chart.Metadata.Version)
+		}
+
+		logrus.Infof("Waiting for the workloads of app %s to be active...", app.Name)
+		err = charts.WatchAndWaitDeployments(client, clusterID, app.Namespace, metav1.ListOptions{})
+		require.NoError(t, err)
+
+		err = charts.WatchAndWaitDaemonSets(client, clusterID, app.Namespace, metav1.ListOptions{})
+		require.NoError(t, err)
+
+		err = charts.WatchAndWaitStatefulSets(client, clusterID, app.Namespace, metav1.ListOptions{})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,255 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/workloads"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tests/actions/services"
+	"github.com/rancher/tests/actions/workloads/deployment"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/cloudproviders"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultNamespace = "default"
+	nginxImage       = "nginx"
+	busyboxImage     = "busybox"
+
+	loadBalancerPrefix = "lb"
+	pvcPrefix          = "pvc"
+	pvcReaderPrefix    = "pvc-reader"
+	pvcWriterPrefix    = "pvc-writer"
+	pvcVolumeName      = "pvc-volume"
+	pvcMountPath       = "/data"
+	pvcMarkerFile      = pvcMountPath + "/marker"
+	pvcStorageSize     = "1Gi"
+
+	harvesterIPAMAnnotation = "cloudprovider.harvesterhci.io/ipam"
+	harvesterIPAMDHCP       = "dhcp"
+)
+
+// VerifyLoadBalancer validates that a LoadBalancer service is assigned an external address by the cloud provider.
+func VerifyLoadBalancer(t *testing.T, client *rancher.Client, clusterID string, terraformConfig *config.TerraformConfig) {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	deploymentName := namegen.AppendRandomString(loadBalancerPrefix)
+	containerTemplate := workloads.NewContainer(nginxImage, nginxImage, corev1.PullAlways, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+	deploymentTemplate := workloads.NewDeploymentTemplate(deploymentName, defaultNamespace, podTemplate, true, nil)
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).Create(deploymentTemplate)
+	require.NoError(t, err)
+
+	err = deployment.VerifyDeployment(steveClient, deploymentResp)
+	require.NoError(t, err)
+
+	annotations := map[string]string{}
+	if terraformConfig.CloudProvider == cloudproviders.Harvester {
+		annotations[harvesterIPAMAnnotation] = harvesterIPAMDHCP
+	}
+
+	ports := []corev1.ServicePort{{Name: "port", Port: 80}}
+	serviceTemplate := services.NewServiceTemplateWithAnnotations(deploymentName, defaultNamespace, corev1.ServiceTypeLoadBalancer, ports,
+		deploymentTemplate.Spec.Template.Labels, annotations)
+
+	serviceResp, err := services.CreateService(steveClient, serviceTemplate)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for LoadBalancer service %s to be assigned an external address...", serviceResp.Name)
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.TenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		service, err := steveClient.SteveType(stevetypes.Service).ByID(serviceResp.ID)
+		if err != nil {
+			return false, nil
+		}
+
+		serviceStatus := &corev1.ServiceStatus{}
+		err = steveV1.ConvertToK8sType(service.Status, serviceStatus)
+		if err != nil {
+			return false, err
+		}
+
+		for _, ingress := range serviceStatus.LoadBalancer.Ingress {
+			if ingress.IP != "" || ingress.Hostname != "" {
+				logrus.Infof("LoadBalancer service %s has external address %s%s", serviceResp.Name, ingress.IP, ingress.Hostname)
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	err = steveClient.SteveType(stevetypes.Service).Delete(serviceResp)
+	require.NoError(t, err)
+
+	err = steveClient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	require.NoError(t, err)
+}
+
+// VerifyPersistentVolume validates that a PVC using the default storage class binds and that its data survives
+// the pod consuming it being rescheduled. The node of the writer pod is cordoned, so the reader pod is scheduled
+// on a different node and the volume has to be detached and reattached by the storage driver.
+func VerifyPersistentVolume(t *testing.T, client *rancher.Client, clusterID string) {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	pvcTemplate := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namegen.AppendRandomString(pvcPrefix),
+			Namespace: defaultNamespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(pvcStorageSize),
+				},
+			},
+		},
+	}
+
+	pvcResp, err := steveClient.SteveType(stevetypes.PersistentVolumeClaim).Create(pvcTemplate)
+	require.NoError(t, err)
+
+	writerCommand := []string{"/bin/sh", "-c", "date > " + pvcMarkerFile + " && sleep 3600"}
+	writerResp := createPVCDeployment(t, steveClient, pvcWriterPrefix, pvcTemplate.Name, writerCommand)
+
+	logrus.Infof("Waiting for PVC %s to be bound...", pvcTemplate.Name)
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		pvc, err := steveClient.SteveType(stevetypes.PersistentVolumeClaim).ByID(pvcResp.ID)
+		if err != nil {
+			return false, nil
+		}
+
+		pvcStatus := &corev1.PersistentVolumeClaimStatus{}
+		err = steveV1.ConvertToK8sType(pvc.Status, pvcStatus)
+		if err != nil {
+			return false, err
+		}
+
+		return pvcStatus.Phase == corev1.ClaimBound, nil
+	})
+	require.NoError(t, err)
+
+	writerNode, err := deploymentNodeName(steveClient, writerResp.Name)
+	require.NoError(t, err)
+
+	logrus.Infof("Cordoning node %s to reschedule the pod consuming PVC %s...", writerNode, pvcTemplate.Name)
+	err = setNodeUnschedulable(steveClient, writerNode, true)
+	require.NoError(t, err)
+
+	defer func() {
+		err = setNodeUnschedulable(steveClient, writerNode, false)
+		require.NoError(t, err)
+	}()
+
+	err = steveClient.SteveType(stevetypes.Deployment).Delete(writerResp)
+	require.NoError(t, err)
+
+	readerCommand := []string{"/bin/sh", "-c", "cat " + pvcMarkerFile + " && sleep 3600"}
+	readerResp := createPVCDeployment(t, steveClient, pvcReaderPrefix, pvcTemplate.Name, readerCommand)
+
+	readerNode, err := deploymentNodeName(steveClient, readerResp.Name)
+	require.NoError(t, err)
+	require.NotEqual(t, writerNode, readerNode, "Pod consuming PVC %s was not rescheduled to a different node", pvcTemplate.Name)
+
+	logrus.Infof("PVC %s was reattached on node %s", pvcTemplate.Name, readerNode)
+
+	err = steveClient.SteveType(stevetypes.Deployment).Delete(readerResp)
+	require.NoError(t, err)
+
+	err = steveClient.SteveType(stevetypes.PersistentVolumeClaim).Delete(pvcResp)
+	require.NoError(t, err)
+}
+
+// createPVCDeployment creates a deployment mounting the given PVC and waits for it to become available.
+func createPVCDeployment(t *testing.T, steveClient *steveV1.Client, prefix, claimName string, command []string) *steveV1.SteveAPIObject {
+	volumeMount := corev1.VolumeMount{Name: pvcVolumeName, MountPath: pvcMountPath}
+	volume := corev1.Volume{
+		Name: pvcVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		},
+	}
+
+	containerTemplate := workloads.NewContainer(busyboxImage, busyboxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{volumeMount}, []corev1.EnvFromSource{}, command, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{volume}, []corev1.LocalObjectReference{}, nil, nil)
+	deploymentTemplate := workloads.NewDeploymentTemplate(namegen.AppendRandomString(prefix), defaultNamespace, podTemplate, true, nil)
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).Create(deploymentTemplate)
+	require.NoError(t, err)
+
+	err = deployment.VerifyDeployment(steveClient, deploymentResp)
+	require.NoError(t, err)
+
+	return deploymentResp
+}
+
+// deploymentNodeName returns the name of the node the running pod of the given deployment is scheduled on.
+func deploymentNodeName(steveClient *steveV1.Client, deploymentName string) (string, error) {
+	pods, err := steveClient.SteveType(stevetypes.Pod).NamespacedSteveClient(defaultNamespace).List(nil)
+	if err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Data {
+		if !strings.HasPrefix(pod.Name, deploymentName+"-") {
+			continue
+		}
+
+		podStatus := &corev1.PodStatus{}
+		err = steveV1.ConvertToK8sType(pod.Status, podStatus)
+		if err != nil {
+			return "", err
+		}
+
+		if podStatus.Phase != corev1.PodRunning {
+			continue
+		}
+
+		podSpec := &corev1.PodSpec{}
+		err = steveV1.ConvertToK8sType(pod.Spec, podSpec)
+		if err != nil {
+			return "", err
+		}
+
+		return podSpec.NodeName, nil
+	}
+
+	return "", fmt.Errorf("No running pod found for deployment: %v", deploymentName)
+}
+
+// setNodeUnschedulable cordons or uncordons the given node.
+func setNodeUnschedulable(steveClient *steveV1.Client, nodeName string, unschedulable bool) error {
+	nodeResp, err := steveClient.SteveType(stevetypes.Node).ByID(nodeName)
+	if err != nil {
+		return err
+	}
+
+	node := &corev1.Node{}
+	err = steveV1.ConvertToK8sType(nodeResp.JSONResp, node)
+	if err != nil {
+		return err
+	}
+
+	node.Spec.Unschedulable = unschedulable
+
+	_, err = steveClient.SteveType(stevetypes.Node).Update(nodeResp, node)
+
+	return err
+}
@@ -0,0 +1,77 @@
+package provisioning
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	fleetDefaultNamespace = "fleet-default"
+	capiClusterNameLabel  = "cluster.x-k8s.io/cluster-name"
+)
+
+// VerifyClusterDeletion validates that a destroyed cluster is removed from Rancher, along with its provisioning cluster,
+// machines and machine sets in the fleet-default namespace.
+func VerifyClusterDeletion(t *testing.T, client *rancher.Client, clusterName string) {
+	nameQuery := url.Values{"fieldSelector": {"metadata.name=" + clusterName}}
+	clusterQuery := url.Values{"labelSelector": {capiClusterNameLabel + "=" + clusterName}}
+
+	capiResources := []struct {
+		steveType string
+		query     url.Values
+	}{
+		{stevetypes.Provisioning, nameQuery},
+		{stevetypes.Machine, clusterQuery},
+		{stevetypes.MachineSet, clusterQuery},
+	}
+
+	logrus.Infof("Waiting for cluster %s to be removed from Rancher...", clusterName)
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
+		if err != nil || clusterID != "" {
+			return false, nil
+		}
+
+		for _, resource := range capiResources {
+			resources, err := client.Steve.SteveType(resource.steveType).NamespacedSteveClient(fleetDefaultNamespace).List(resource.query)
+			if err != nil {
+				return false, nil
+			}
+
+			if len(resources.Data) > 0 {
+				logrus.Infof("Waiting for %v %s objects of cluster %s to be removed...", len(resources.Data), resource.steveType, clusterName)
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	require.NoError(t, err)
+}
+
+// VerifyNoOrphanedInstances validates that none of the provider instances named after the resource prefix of the cluster is
+// left running once the cluster is destroyed. Instances can take a few minutes to terminate, so the provider is polled before
+// failing.
+func VerifyNoOrphanedInstances(t *testing.T, finder InstanceFinder, resourcePrefix string) {
+	var orphans []Instance
+
+	logrus.Infof("Checking for orphaned instances of %s...", resourcePrefix)
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.TenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		orphans, err = FindRunningInstances(finder, resourcePrefix)
+		if err != nil {
+			return false, err
+		}
+
+		return len(orphans) == 0, nil
+	})
+	require.NoErrorf(t, err, "Found %v orphaned instances: %v", len(orphans), orphans)
+}
@@ -0,0 +1,118 @@
+package provisioning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// VerifyHostedImport validates that Rancher discovered the node pools of an imported hosted cluster without taking over
+// their management. It returns the discovered pools, keyed by name, so they can be looked up in the cloud provider once the
+// cluster is removed from Rancher.
+func VerifyHostedImport(t *testing.T, client *rancher.Client, clusterID, module string) map[string]string {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	discoveredPools := map[string]string{}
+
+	// Autoscaled or imported pools may not report a node count, in which case the nodes of the cluster can not be matched.
+	var nodeCount int64
+	countsKnown := true
+
+	switch {
+	case strings.Contains(module, clustertypes.AKS):
+		require.NotNil(t, cluster.AKSConfig)
+		require.True(t, cluster.AKSConfig.Imported)
+		require.Nil(t, cluster.AKSConfig.NodePools, "Rancher must not manage the node pools of an imported AKS cluster")
+		require.NotNil(t, cluster.AKSStatus)
+		require.NotNil(t, cluster.AKSStatus.UpstreamSpec)
+		require.NotNil(t, cluster.AKSStatus.UpstreamSpec.NodePools)
+
+		for _, pool := range *cluster.AKSStatus.UpstreamSpec.NodePools {
+			discoveredPools[stringValue(pool.Name)] = fmt.Sprintf("vmSize=%s,count=%s,version=%s", pool.VMSize, int64Value(pool.Count),
+				stringValue(pool.OrchestratorVersion))
+
+			if pool.Count == nil {
+				countsKnown = false
+			} else {
+				nodeCount += *pool.Count
+			}
+		}
+	case strings.Contains(module, clustertypes.EKS):
+		require.NotNil(t, cluster.EKSConfig)
+		require.True(t, cluster.EKSConfig.Imported)
+		require.Nil(t, cluster.EKSConfig.NodeGroups, "Rancher must not manage the node groups of an imported EKS cluster")
+		require.NotNil(t, cluster.EKSStatus)
+		require.NotNil(t, cluster.EKSStatus.UpstreamSpec)
+		require.NotNil(t, cluster.EKSStatus.UpstreamSpec.NodeGroups)
+
+		for _, nodeGroup := range *cluster.EKSStatus.UpstreamSpec.NodeGroups {
+			discoveredPools[stringValue(nodeGroup.NodegroupName)] = fmt.Sprintf("instanceType=%s,desiredSize=%s,version=%s",
+				stringValue(nodeGroup.InstanceType), int64Value(nodeGroup.DesiredSize), stringValue(nodeGroup.Version))
+
+			if nodeGroup.DesiredSize == nil {
+				countsKnown = false
+			} else {
+				nodeCount += *nodeGroup.DesiredSize
+			}
+		}
+	case strings.Contains(module, clustertypes.GKE):
+		require.NotNil(t, cluster.GKEConfig)
+		require.True(t, cluster.GKEConfig.Imported)
+		require.Nil(t, cluster.GKEConfig.NodePools, "Rancher must not manage the node pools of an imported GKE cluster")
+		require.NotNil(t, cluster.GKEStatus)
+		require.NotNil(t, cluster.GKEStatus.UpstreamSpec)
+		require.NotNil(t, cluster.GKEStatus.UpstreamSpec.NodePools)
+
+		for _, pool := range *cluster.GKEStatus.UpstreamSpec.NodePools {
+			var machineType string
+			if pool.Config != nil {
+				machineType = pool.Config.MachineType
+			}
+
+			discoveredPools[stringValue(pool.Name)] = fmt.Sprintf("machineType=%s,initialNodeCount=%s,version=%s", machineType,
+				int64Value(pool.InitialNodeCount), stringValue(pool.Version))
+		}
+
+		// GKE reports the initial node count per zone, so the nodes of the cluster can not be matched.
+		countsKnown = false
+	default:
+		require.Failf(t, "Unsupported module", "Unsupported module: %v", module)
+	}
+
+	require.NotEmpty(t, discoveredPools, "Rancher did not discover any node pools")
+
+	for name, pool := range discoveredPools {
+		logrus.Infof("Discovered pool %s: %s", name, pool)
+	}
+
+	if countsKnown {
+		require.Equal(t, nodeCount, cluster.NodeCount)
+	}
+
+	return discoveredPools
+}
+
+// stringValue returns the value of a string pointer, or an empty string if it is nil.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+// int64Value returns the value of an int64 pointer as a string, or an empty string if it is nil.
+func int64Value(value *int64) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*value, 10)
+}
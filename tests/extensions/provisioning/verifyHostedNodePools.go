@@ -0,0 +1,230 @@
+package provisioning
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	aksNodePoolLabel = "kubernetes.azure.com/agentpool"
+	eksNodePoolLabel = "eks.amazonaws.com/nodegroup"
+	gkeNodePoolLabel = "cloud.google.com/gke-nodepool"
+)
+
+// hostedSpotLabels are the node labels, and their values, marking the nodes of spot (AKS, EKS) or preemptible (GKE) pools.
+var hostedSpotLabels = map[string][2]string{
+	clustertypes.AKS: {"kubernetes.azure.com/scalesetpriority", "spot"},
+	clustertypes.EKS: {"eks.amazonaws.com/capacityType", "SPOT"},
+	clustertypes.GKE: {"cloud.google.com/gke-preemptible", "true"},
+}
+
+// VerifyHostedNodePools validates that every pool of a hosted cluster has the expected number of nodes, and that the
+// nodes of each pool carry the labels and taints of the pool and run on spot instances if the pool is a spot pool.
+func VerifyHostedNodePools(t *testing.T, client *rancher.Client, clusterID string, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	poolLabel, poolNames := hostedNodePoolNames(t, cluster, terraformConfig.Module)
+	require.Len(t, poolNames, len(terratestConfig.Nodepools))
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	nodes, err := steveClient.SteveType(stevetypes.Node).List(nil)
+	require.NoError(t, err)
+
+	var poolZones map[string]int64
+	if terraformConfig.Module == clustertypes.GKE {
+		poolZones, err = gkeNodePoolZones(terraformConfig, cluster.GKEConfig.ClusterName)
+		require.NoError(t, err)
+	}
+
+	for i, pool := range terratestConfig.Nodepools {
+		poolName := poolNames[i]
+
+		var poolNodes []steveV1.SteveAPIObject
+		for _, node := range nodes.Data {
+			if node.Labels[poolLabel] == poolName {
+				poolNodes = append(poolNodes, node)
+			}
+		}
+
+		logrus.Infof("Verifying %v nodes of pool %s...", len(poolNodes), poolName)
+		zones := int64(1)
+		if terraformConfig.Module == clustertypes.GKE {
+			zones = poolZones[poolName]
+			require.NotZerof(t, zones, "pool %s was not found in the GKE cluster", poolName)
+		}
+
+		verifyHostedNodePoolCount(t, terraformConfig.Module, poolName, pool, zones, int64(len(poolNodes)))
+
+		for _, node := range poolNodes {
+			for key, value := range pool.Labels {
+				require.Equalf(t, value, node.Labels[key], "node %s is missing label %s=%s", node.Name, key, value)
+			}
+
+			if pool.Spot {
+				spotLabel := hostedSpotLabels[terraformConfig.Module]
+				require.Equalf(t, spotLabel[1], node.Labels[spotLabel[0]], "node %s of spot pool %s is not a spot instance", node.Name, poolName)
+			}
+
+			nodeSpec := &corev1.NodeSpec{}
+			err = steveV1.ConvertToK8sType(node.Spec, nodeSpec)
+			require.NoError(t, err)
+
+			for _, taint := range pool.Taints {
+				expectedTaint, err := format.ParseTaint(taint)
+				require.NoError(t, err)
+
+				found := false
+				for _, nodeTaint := range nodeSpec.Taints {
+					if nodeTaint.MatchTaint(&expectedTaint) && nodeTaint.Value == expectedTaint.Value {
+						found = true
+						break
+					}
+				}
+
+				require.Truef(t, found, "node %s is missing taint %s", node.Name, taint)
+			}
+		}
+	}
+}
+
+// hostedNodePoolNames returns the node label identifying the pool of a node, and the pool names of the hosted cluster
+// in the order they were configured.
+func hostedNodePoolNames(t *testing.T, cluster *management.Cluster, module string) (string, []string) {
+	var poolNames []string
+
+	switch module {
+	case clustertypes.AKS:
+		for _, pool := range *cluster.AKSConfig.NodePools {
+			poolNames = append(poolNames, *pool.Name)
+		}
+
+		return aksNodePoolLabel, poolNames
+	case clustertypes.EKS:
+		for _, nodeGroup := range *cluster.EKSConfig.NodeGroups {
+			poolNames = append(poolNames, *nodeGroup.NodegroupName)
+		}
+
+		return eksNodePoolLabel, poolNames
+	case clustertypes.GKE:
+		for _, pool := range *cluster.GKEConfig.NodePools {
+			poolNames = append(poolNames, *pool.Name)
+		}
+
+		return gkeNodePoolLabel, poolNames
+	default:
+		require.Failf(t, "Unsupported module", "Unsupported module: %v", module)
+	}
+
+	return "", nil
+}
+
+// verifyHostedNodePoolCount validates the node count of a pool running in the given number of zones. The sizes of GKE
+// pools are per zone, while AKS and EKS pools only report one zone. Autoscaled pools only need to be within their bounds.
+func verifyHostedNodePoolCount(t *testing.T, module, poolName string, pool config.Nodepool, zones, nodeCount int64) {
+	if pool.MaxSize > 0 && module != clustertypes.EKS {
+		require.GreaterOrEqualf(t, nodeCount, pool.MinSize*zones, "pool %s has fewer nodes than its min size", poolName)
+		require.LessOrEqualf(t, nodeCount, pool.MaxSize*zones, "pool %s has more nodes than its max size", poolName)
+
+		return
+	}
+
+	expectedNodeCount := pool.Quantity * zones
+	if module == clustertypes.EKS {
+		expectedNodeCount = pool.DesiredSize
+	}
+
+	require.Equalf(t, expectedNodeCount, nodeCount, "pool %s does not have the expected number of nodes", poolName)
+}
+
+// VerifyHostedControlPlaneVersion validates that the control plane of a hosted cluster runs the expected Kubernetes version.
+func VerifyHostedControlPlaneVersion(t *testing.T, client *rancher.Client, clusterID, expectedVersion string) {
+	logrus.Infof("Waiting for the control plane to be on version %s...", expectedVersion)
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil || cluster.Version == nil {
+			return false, nil
+		}
+
+		return matchesKubernetesVersion(cluster.Version.GitVersion, expectedVersion), nil
+	})
+	require.NoError(t, err)
+}
+
+// VerifyHostedNodePoolVersions validates that the kubelet of every node runs the Kubernetes version of its pool, which
+// defaults to the Kubernetes version of the cluster.
+func VerifyHostedNodePoolVersions(t *testing.T, client *rancher.Client, clusterID string, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	poolLabel, poolNames := hostedNodePoolNames(t, cluster, terraformConfig.Module)
+	require.Len(t, poolNames, len(terratestConfig.Nodepools))
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	for i, pool := range terratestConfig.Nodepools {
+		expectedVersion := pool.KubernetesVersion
+		if expectedVersion == "" {
+			expectedVersion = terratestConfig.KubernetesVersion
+		}
+
+		logrus.Infof("Waiting for the nodes of pool %s to be on version %s...", poolNames[i], expectedVersion)
+		err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+			nodes, err := steveClient.SteveType(stevetypes.Node).List(nil)
+			if err != nil {
+				return false, nil
+			}
+
+			poolNodes := 0
+			for _, node := range nodes.Data {
+				if node.Labels[poolLabel] != poolNames[i] {
+					continue
+				}
+
+				nodeStatus := &corev1.NodeStatus{}
+				err = steveV1.ConvertToK8sType(node.Status, nodeStatus)
+				if err != nil {
+					return false, err
+				}
+
+				if !matchesKubernetesVersion(nodeStatus.NodeInfo.KubeletVersion, expectedVersion) {
+					return false, nil
+				}
+
+				poolNodes++
+			}
+
+			return poolNodes > 0, nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+// matchesKubernetesVersion returns true if the reported version, e.g. v1.30.4-eks-a737599, is the expected version. EKS
+// only expects a minor version, while AKS and GKE expect a patch version.
+func matchesKubernetesVersion(reportedVersion, expectedVersion string) bool {
+	reportedVersion = strings.TrimPrefix(reportedVersion, "v")
+	expectedVersion = strings.TrimPrefix(expectedVersion, "v")
+
+	return reportedVersion == expectedVersion || strings.HasPrefix(reportedVersion, expectedVersion+".") ||
+		strings.HasPrefix(reportedVersion, expectedVersion+"-")
+}
@@ -0,0 +1,262 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/workloads"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tests/actions/services"
+	"github.com/rancher/tests/actions/workloads/deployment"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	osLabel           = "kubernetes.io/os"
+	windowsBuildLabel = "node.kubernetes.io/windows-build"
+	linuxOS           = "linux"
+	windowsOS         = "windows"
+
+	serverCoreImage = "mcr.microsoft.com/windows/servercore"
+	nanoServerImage = "mcr.microsoft.com/windows/nanoserver"
+	curlImage       = "curlimages/curl"
+
+	windowsDaemonSetPrefix  = "windows-ds"
+	windowsDeploymentPrefix = "windows-web"
+	windowsJobPrefix        = "windows-curl"
+	windowsResponse         = "windows"
+	windowsWebPort          = 80
+
+	// A PowerShell web server, since the servercore image does not ship one.
+	windowsWebServer = `$listener = New-Object System.Net.HttpListener; $listener.Prefixes.Add('http://*:80/'); $listener.Start(); ` +
+		`while ($listener.IsListening) { $context = $listener.GetContext(); ` +
+		`$content = [System.Text.Encoding]::UTF8.GetBytes('` + windowsResponse + `'); ` +
+		`$context.Response.OutputStream.Write($content, 0, $content.Length); $context.Response.Close() }`
+)
+
+// Windows containers must run the same build as the node, so the ltsc tag is chosen from the node's build number.
+var windowsImageTags = map[string]string{
+	"17763": "ltsc2019",
+	"20348": "ltsc2022",
+	"26100": "ltsc2025",
+}
+
+// Annotations set on every node by the CNI once it has configured the node's networking.
+var cniNodeAnnotations = map[string]string{
+	"calico":  "projectcalico.org/IPv4Address",
+	"flannel": "flannel.alpha.coreos.com/public-ip",
+}
+
+// VerifyWindowsWorkloads validates that Windows workloads are able to run on the Windows nodes of mixed-OS clusters. For every
+// Windows build in the cluster, a servercore Deployment is exposed through a NodePort service that must be reachable from a
+// Linux pod, and a nanoserver DaemonSet must roll out on every node of the build.
+func VerifyWindowsWorkloads(t *testing.T, client *rancher.Client, clusterIDs []string, terraformConfig *config.TerraformConfig) {
+	for _, clusterID := range clusterIDs {
+		steveClient, err := client.Steve.ProxyDownstream(clusterID)
+		require.NoError(t, err)
+
+		nodes, err := steveClient.SteveType(stevetypes.Node).List(nil)
+		require.NoError(t, err)
+
+		windowsNodes := map[string][]steveV1.SteveAPIObject{}
+		for _, node := range nodes.Data {
+			if node.Labels[osLabel] == windowsOS {
+				windowsNodes[node.Labels[windowsBuildLabel]] = append(windowsNodes[node.Labels[windowsBuildLabel]], node)
+			}
+		}
+
+		require.NotEmptyf(t, windowsNodes, "cluster %s has no Windows nodes", clusterID)
+
+		for windowsBuild, buildNodes := range windowsNodes {
+			verifyWindowsNetworking(t, buildNodes, terraformConfig.CNI)
+
+			imageTag, err := windowsImageTag(windowsBuild)
+			require.NoError(t, err)
+
+			logrus.Infof("Validating Windows %s workloads on %v nodes...", imageTag, len(buildNodes))
+
+			nodeSelector := map[string]string{
+				osLabel:           windowsOS,
+				windowsBuildLabel: windowsBuild,
+			}
+
+			deploymentResp, serviceResp := createWindowsWebDeployment(t, steveClient, imageTag, nodeSelector)
+			verifyWindowsNodePort(t, steveClient, serviceResp, buildNodes)
+			verifyWindowsDaemonSet(t, steveClient, imageTag, nodeSelector, len(buildNodes))
+
+			err = steveClient.SteveType(stevetypes.Service).Delete(serviceResp)
+			require.NoError(t, err)
+
+			err = steveClient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+			require.NoError(t, err)
+		}
+	}
+}
+
+// windowsImageTag returns the ltsc tag of the Windows images matching the build label of a node, e.g. 10.0.20348.
+func windowsImageTag(windowsBuild string) (string, error) {
+	buildNumber := windowsBuild[strings.LastIndex(windowsBuild, ".")+1:]
+
+	imageTag, ok := windowsImageTags[buildNumber]
+	if !ok {
+		return "", fmt.Errorf("Unsupported Windows build: %v", windowsBuild)
+	}
+
+	return imageTag, nil
+}
+
+// verifyWindowsNetworking validates that the CNI configured the networking of the Windows nodes. Only Calico and Flannel
+// support Windows nodes.
+func verifyWindowsNetworking(t *testing.T, nodes []steveV1.SteveAPIObject, cni string) {
+	annotation, ok := cniNodeAnnotations[cni]
+	if !ok {
+		logrus.Warningf("Skipping Windows networking validation for CNI %s", cni)
+		return
+	}
+
+	for _, node := range nodes {
+		require.NotEmptyf(t, node.Annotations[annotation], "Windows node %s is missing %s annotation %s", node.Name, cni, annotation)
+
+		nodeSpec := &corev1.NodeSpec{}
+		err := steveV1.ConvertToK8sType(node.Spec, nodeSpec)
+		require.NoError(t, err)
+		require.NotEmptyf(t, nodeSpec.PodCIDR, "Windows node %s was not assigned a pod CIDR", node.Name)
+	}
+}
+
+// createWindowsWebDeployment creates a servercore deployment serving HTTP, exposes it through a NodePort service, and
+// waits for the deployment to become available.
+func createWindowsWebDeployment(t *testing.T, steveClient *steveV1.Client, imageTag string,
+	nodeSelector map[string]string) (*steveV1.SteveAPIObject, *steveV1.SteveAPIObject) {
+	deploymentName := namegen.AppendRandomString(windowsDeploymentPrefix)
+	command := []string{"powershell.exe", "-Command", windowsWebServer}
+
+	containerTemplate := workloads.NewContainer(windowsDeploymentPrefix, serverCoreImage+":"+imageTag, corev1.PullIfNotPresent,
+		[]corev1.VolumeMount{}, []corev1.EnvFromSource{}, command, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nodeSelector)
+	deploymentTemplate := workloads.NewDeploymentTemplate(deploymentName, defaultNamespace, podTemplate, true, nil)
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).Create(deploymentTemplate)
+	require.NoError(t, err)
+
+	err = deployment.VerifyDeployment(steveClient, deploymentResp)
+	require.NoError(t, err)
+
+	ports := []corev1.ServicePort{{Name: "port", Port: windowsWebPort}}
+	serviceTemplate := services.NewServiceTemplate(deploymentName, defaultNamespace, corev1.ServiceTypeNodePort, ports,
+		deploymentTemplate.Spec.Template.Labels)
+
+	serviceResp, err := services.CreateService(steveClient, serviceTemplate)
+	require.NoError(t, err)
+
+	return deploymentResp, serviceResp
+}
+
+// verifyWindowsNodePort validates that the NodePort service is reachable on every Windows node from a Linux pod.
+func verifyWindowsNodePort(t *testing.T, steveClient *steveV1.Client, serviceResp *steveV1.SteveAPIObject, nodes []steveV1.SteveAPIObject) {
+	service, err := steveClient.SteveType(stevetypes.Service).ByID(serviceResp.ID)
+	require.NoError(t, err)
+
+	serviceSpec := &corev1.ServiceSpec{}
+	err = steveV1.ConvertToK8sType(service.Spec, serviceSpec)
+	require.NoError(t, err)
+	require.NotEmpty(t, serviceSpec.Ports)
+
+	nodePort := serviceSpec.Ports[0].NodePort
+
+	var curlCommands []string
+	for _, node := range nodes {
+		nodeStatus := &corev1.NodeStatus{}
+		err = steveV1.ConvertToK8sType(node.Status, nodeStatus)
+		require.NoError(t, err)
+
+		for _, address := range nodeStatus.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				curlCommands = append(curlCommands, fmt.Sprintf("curl -sf --retry 30 --retry-delay 10 --retry-all-errors http://%s:%d | grep %s",
+					address.Address, nodePort, windowsResponse))
+			}
+		}
+	}
+
+	require.NotEmpty(t, curlCommands)
+
+	jobTemplate := workloads.NewJobTemplate(namegen.AppendRandomString(windowsJobPrefix), defaultNamespace)
+	jobTemplate.Namespace = defaultNamespace
+	jobTemplate.Spec.Template.Spec.NodeSelector = map[string]string{osLabel: linuxOS}
+	jobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
+		workloads.NewContainer(windowsJobPrefix, curlImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{},
+			[]string{"/bin/sh", "-c", strings.Join(curlCommands, " && ")}, nil, nil),
+	}
+
+	jobResp, err := steveClient.SteveType(stevetypes.Job).Create(jobTemplate)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for Linux pod to reach NodePort %v on the Windows nodes...", nodePort)
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.TenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		job, err := steveClient.SteveType(stevetypes.Job).ByID(jobResp.ID)
+		if err != nil {
+			return false, nil
+		}
+
+		jobStatus := &batchv1.JobStatus{}
+		err = steveV1.ConvertToK8sType(job.Status, jobStatus)
+		if err != nil {
+			return false, err
+		}
+
+		if jobStatus.Failed > 0 {
+			return false, fmt.Errorf("Linux pod failed to reach NodePort %v on the Windows nodes", nodePort)
+		}
+
+		return jobStatus.Succeeded > 0, nil
+	})
+	require.NoError(t, err)
+
+	err = steveClient.SteveType(stevetypes.Job).Delete(jobResp)
+	require.NoError(t, err)
+}
+
+// verifyWindowsDaemonSet validates that a nanoserver DaemonSet rolls out on every Windows node of the build.
+func verifyWindowsDaemonSet(t *testing.T, steveClient *steveV1.Client, imageTag string, nodeSelector map[string]string, nodeCount int) {
+	command := []string{"cmd.exe", "/c", "ping -t localhost"}
+
+	containerTemplate := workloads.NewContainer(windowsDaemonSetPrefix, nanoServerImage+":"+imageTag, corev1.PullIfNotPresent,
+		[]corev1.VolumeMount{}, []corev1.EnvFromSource{}, command, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nodeSelector)
+	daemonSetTemplate := workloads.NewDaemonSetTemplate(namegen.AppendRandomString(windowsDaemonSetPrefix), defaultNamespace, podTemplate, true, nil)
+
+	daemonSetResp, err := steveClient.SteveType(stevetypes.DaemonSet).Create(daemonSetTemplate)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for DaemonSet %s to roll out on %v Windows nodes...", daemonSetTemplate.Name, nodeCount)
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		daemonSet, err := steveClient.SteveType(stevetypes.DaemonSet).ByID(daemonSetResp.ID)
+		if err != nil {
+			return false, nil
+		}
+
+		daemonSetStatus := &appv1.DaemonSetStatus{}
+		err = steveV1.ConvertToK8sType(daemonSet.Status, daemonSetStatus)
+		if err != nil {
+			return false, err
+		}
+
+		return daemonSetStatus.DesiredNumberScheduled == int32(nodeCount) && daemonSetStatus.NumberReady == int32(nodeCount), nil
+	})
+	require.NoError(t, err)
+
+	err = steveClient.SteveType(stevetypes.DaemonSet).Delete(daemonSetResp)
+	require.NoError(t, err)
+}
@@ -0,0 +1,326 @@
+package rbac
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rancher/norman/types"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	setrbac "github.com/rancher/tfp-automation/framework/set/rbac"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clusterContext = "cluster"
+	projectContext = "project"
+	globalContext  = "global"
+	globalRoleID   = "globalRoleId"
+	name           = "name"
+	roleTemplateID = "roleTemplateId"
+	wildcard       = "*"
+)
+
+// ruleResources are the resources of policy rules that the permissions of custom roles are checked against. Rules on other resources
+// are not checked.
+var ruleResources = []struct {
+	apiGroup   string
+	resource   string
+	steveType  string
+	namespaced bool
+}{
+	{"", "configmaps", stevetypes.ConfigMap, true},
+	{"", "secrets", stevetypes.Secret, true},
+	{"apps", "deployments", stevetypes.Deployment, true},
+	{"catalog.cattle.io", "clusterrepos", clusterRepoSteveType, false},
+}
+
+// VerifyCustomRoles is a function that will verify the custom role templates and global roles of the config were created with their
+// context, inheritance and rules under their resource prefixed names, and that each of them is bound to a user on the cluster, its project or globally.
+func VerifyCustomRoles(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	for _, customRoleTemplate := range terraformConfig.RoleTemplates {
+		roleTemplateName := setrbac.CustomRoleName(terraformConfig, customRoleTemplate.Name)
+		logrus.Infof("Verifying role template %s...", roleTemplateName)
+
+		roleTemplates, err := client.Management.RoleTemplate.ListAll(&types.ListOpts{
+			Filters: map[string]any{
+				name: roleTemplateName,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, roleTemplates.Data, 1)
+
+		roleTemplate := roleTemplates.Data[0]
+		require.Equal(t, customRoleTemplate.Context, roleTemplate.Context)
+		require.ElementsMatch(t, customRoleTemplate.RoleTemplateIDs, roleTemplate.RoleTemplateIDs)
+		require.ElementsMatch(t, customRoleTemplate.Rules, roleTemplate.Rules)
+
+		filters := &types.ListOpts{
+			Filters: map[string]any{
+				roleTemplateID: roleTemplate.ID,
+			},
+		}
+
+		if customRoleTemplate.Context == clusterContext {
+			bindings, err := client.Management.ClusterRoleTemplateBinding.ListAll(filters)
+			require.NoError(t, err)
+			require.Len(t, bindings.Data, 1)
+			require.Equal(t, clusterID, bindings.Data[0].ClusterID)
+			require.NotEmpty(t, bindings.Data[0].UserID)
+		} else {
+			bindings, err := client.Management.ProjectRoleTemplateBinding.ListAll(filters)
+			require.NoError(t, err)
+			require.Len(t, bindings.Data, 1)
+			require.True(t, strings.HasPrefix(bindings.Data[0].ProjectID, clusterID+":"))
+			require.NotEmpty(t, bindings.Data[0].UserID)
+		}
+	}
+
+	for _, customGlobalRole := range terraformConfig.GlobalRoles {
+		globalRoleName := setrbac.CustomRoleName(terraformConfig, customGlobalRole.Name)
+		logrus.Infof("Verifying global role %s...", globalRoleName)
+
+		globalRoles, err := client.Management.GlobalRole.ListAll(&types.ListOpts{
+			Filters: map[string]any{
+				name: globalRoleName,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, globalRoles.Data, 1)
+
+		globalRole := globalRoles.Data[0]
+		require.False(t, globalRole.NewUserDefault)
+		require.ElementsMatch(t, customGlobalRole.InheritedClusterRoles, globalRole.InheritedClusterRoles)
+		require.ElementsMatch(t, customGlobalRole.Rules, globalRole.Rules)
+
+		bindings, err := client.Management.GlobalRoleBinding.ListAll(&types.ListOpts{
+			Filters: map[string]any{
+				globalRoleID: globalRole.ID,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, bindings.Data, 1)
+		require.NotEmpty(t, bindings.Data[0].UserID)
+	}
+}
+
+// verifyCustomRolePermissions builds the permission matrix of every custom role template and global role of the config from its rules,
+// including the rules of the role templates it inherits from, and verifies it with the token of the user bound to the role.
+func verifyCustomRolePermissions(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig, clusterID string) {
+	for _, customRoleTemplate := range terraformConfig.RoleTemplates {
+		roleTemplateName := setrbac.CustomRoleName(terraformConfig, customRoleTemplate.Name)
+
+		t.Run(roleTemplateName, func(t *testing.T) {
+			roleTemplates, err := client.Management.RoleTemplate.ListAll(&types.ListOpts{
+				Filters: map[string]any{
+					name: roleTemplateName,
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, roleTemplates.Data, 1)
+
+			roleTemplate := roleTemplates.Data[0]
+
+			rules, err := roleTemplateRules(client, &roleTemplate)
+			require.NoError(t, err)
+
+			permissions := rulePermissions(rules, customRoleTemplate.Context)
+			requireAllowedAndDenied(t, roleTemplateName, permissions)
+
+			user, projectID, err := roleTemplateUser(client, clusterID, roleTemplate.ID, customRoleTemplate.Context)
+			require.NoError(t, err)
+
+			runPermissions(t, client, clusterID, roleTemplateName, user, projectID, permissions)
+		})
+	}
+
+	for _, customGlobalRole := range terraformConfig.GlobalRoles {
+		globalRoleName := setrbac.CustomRoleName(terraformConfig, customGlobalRole.Name)
+
+		t.Run(globalRoleName, func(t *testing.T) {
+			globalRoles, err := client.Management.GlobalRole.ListAll(&types.ListOpts{
+				Filters: map[string]any{
+					name: globalRoleName,
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, globalRoles.Data, 1)
+
+			globalRole := globalRoles.Data[0]
+
+			permissions := rulePermissions(globalRole.Rules, globalContext)
+			requireAllowedAndDenied(t, globalRoleName, permissions)
+
+			bindings, err := client.Management.GlobalRoleBinding.ListAll(&types.ListOpts{
+				Filters: map[string]any{
+					globalRoleID: globalRole.ID,
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, bindings.Data, 1)
+
+			user, err := client.Management.User.ByID(bindings.Data[0].UserID)
+			require.NoError(t, err)
+
+			runPermissions(t, client, clusterID, globalRoleName, user, "", permissions)
+		})
+	}
+}
+
+// roleTemplateRules returns the rules of the role template together with the rules of the role templates it inherits from.
+func roleTemplateRules(client *rancher.Client, roleTemplate *management.RoleTemplate) ([]management.PolicyRule, error) {
+	rules := append([]management.PolicyRule{}, roleTemplate.Rules...)
+
+	for _, inheritedID := range roleTemplate.RoleTemplateIDs {
+		inheritedRoleTemplate, err := client.Management.RoleTemplate.ByID(inheritedID)
+		if err != nil {
+			return nil, err
+		}
+
+		inheritedRules, err := roleTemplateRules(client, inheritedRoleTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, inheritedRules...)
+	}
+
+	return rules, nil
+}
+
+// roleTemplateUser returns the user the role template is bound to on the cluster or, for project role templates, in a project of the
+// cluster together with the ID of the project.
+func roleTemplateUser(client *rancher.Client, clusterID, roleTemplateName, roleContext string) (*management.User, string, error) {
+	var userID, projectID string
+
+	filters := &types.ListOpts{
+		Filters: map[string]any{
+			roleTemplateID: roleTemplateName,
+		},
+	}
+
+	if roleContext == clusterContext {
+		bindings, err := client.Management.ClusterRoleTemplateBinding.ListAll(filters)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, binding := range bindings.Data {
+			if binding.ClusterID == clusterID {
+				userID = binding.UserID
+			}
+		}
+	} else {
+		bindings, err := client.Management.ProjectRoleTemplateBinding.ListAll(filters)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, binding := range bindings.Data {
+			if strings.HasPrefix(binding.ProjectID, clusterID+":") {
+				userID, projectID = binding.UserID, binding.ProjectID
+			}
+		}
+	}
+
+	if userID == "" {
+		return nil, "", fmt.Errorf("No binding found for role template %s in cluster: %v", roleTemplateName, clusterID)
+	}
+
+	user, err := client.Management.User.ByID(userID)
+
+	return user, projectID, err
+}
+
+// rulePermissions returns the list and create entries of the permission matrix of a role with the given rules for every checked
+// resource the rules refer to. An entry is allowed when one of the rules grants the verb on the resource and denied otherwise. Cluster
+// roles are checked on the cluster and the default namespace, project roles in a namespace of their project and global roles on the
+// Rancher server, where only resources without a namespace are created.
+func rulePermissions(rules []management.PolicyRule, roleContext string) []permission {
+	var permissions []permission
+
+	for _, ruleResource := range ruleResources {
+		if roleContext == projectContext && !ruleResource.namespaced {
+			continue
+		}
+
+		if !rulesGrant(rules, ruleResource.apiGroup, ruleResource.resource, "") {
+			continue
+		}
+
+		for _, verb := range []string{list, create} {
+			entryScope := clusterScope
+			switch {
+			case roleContext == globalContext:
+				entryScope = globalScope
+			case roleContext == projectContext:
+				entryScope = projectNamespace
+			case verb == create && ruleResource.namespaced:
+				entryScope = otherNamespace
+			}
+
+			if entryScope == globalScope && verb == create && ruleResource.namespaced {
+				continue
+			}
+
+			permissions = append(permissions, permission{
+				verb:     verb,
+				resource: ruleResource.steveType,
+				scope:    entryScope,
+				allowed:  rulesGrant(rules, ruleResource.apiGroup, ruleResource.resource, verb),
+			})
+		}
+	}
+
+	return permissions
+}
+
+// rulesGrant returns whether one of the rules grants the verb on the resource, or refers to the resource at all when the verb is
+// empty. Rules limited to resource names do not grant a list or create.
+func rulesGrant(rules []management.PolicyRule, apiGroup, resource, verb string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+
+		if matches(rule.APIGroups, apiGroup) && matches(rule.Resources, resource) && (verb == "" || matches(rule.Verbs, verb)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches returns whether the values of a rule hold the value or the wildcard.
+func matches(values []string, value string) bool {
+	for _, ruleValue := range values {
+		if ruleValue == value || ruleValue == wildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requireAllowedAndDenied requires the permission matrix of a custom role to hold at least one allowed and one denied entry.
+func requireAllowedAndDenied(t *testing.T, roleName string, permissions []permission) {
+	allowed, denied := false, false
+	for _, entry := range permissions {
+		if entry.allowed {
+			allowed = true
+		} else {
+			denied = true
+		}
+	}
+
+	require.Truef(t, allowed && denied, "Rules of %s must allow and deny at least one list or create on configmaps, secrets, deployments "+
+		"or clusterrepos", roleName)
+}
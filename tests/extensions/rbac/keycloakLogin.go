@@ -0,0 +1,266 @@
+package rbac
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/defaults/authproviders"
+	"github.com/rancher/tfp-automation/framework/set/authproviders/oidc"
+	"github.com/rancher/tfp-automation/framework/set/resources/keycloak"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	finalRedirectPath = "/dashboard/auth/verify"
+	groupPrincipal    = "group"
+	loginAction       = "login"
+	oidcScopes        = "openid profile email"
+	responseTypeJSON  = "json"
+	tokenCookie       = "R_SESS"
+)
+
+var (
+	// loginEndpoints are the v3-public login endpoints of the Keycloak auth providers.
+	loginEndpoints = map[string]string{
+		authproviders.GenericOIDC:  "/v3-public/genericOIDCProviders/genericoidc",
+		authproviders.Keycloak:     "/v3-public/keyCloakProviders/keycloak",
+		authproviders.KeycloakOIDC: "/v3-public/keyCloakOIDCProviders/keycloakoidc",
+	}
+
+	loginFormPattern = regexp.MustCompile(`(?is)<form[^>]*id="kc-form-login".*?</form>`)
+	anyFormPattern   = regexp.MustCompile(`(?is)<form.*?</form>`)
+	actionPattern    = regexp.MustCompile(`(?i)<form[^>]*action="([^"]+)"`)
+	inputPattern     = regexp.MustCompile(`(?i)<input[^>]*name="([^"]+)"[^>]*value="([^"]*)"`)
+)
+
+// VerifyKeycloakLogin is a function that will log in as the test user of the Keycloak realm through the Rancher API login flow of
+// the auth provider, and verify the group the user belongs to in the realm is mapped to a group principal of the user.
+func VerifyKeycloakLogin(t *testing.T, client *rancher.Client, server *keycloak.Server, authProvider string) {
+	httpClient, err := newLoginClient(client.RancherConfig)
+	require.NoError(t, err)
+
+	var token string
+
+	logrus.Infof("Logging in as %s through the %s auth provider...", server.Username, authProvider)
+	switch authProvider {
+	case authproviders.Keycloak:
+		token, err = samlLogin(httpClient, client.RancherConfig.Host, server)
+	case authproviders.KeycloakOIDC, authproviders.GenericOIDC:
+		token, err = oidcLogin(httpClient, client.RancherConfig, server, authProvider)
+	default:
+		err = fmt.Errorf("Unsupported auth provider for Keycloak login: %v", authProvider)
+	}
+	require.NoError(t, err)
+
+	userClient, err := rancher.NewClientForConfig(token, client.RancherConfig, client.Session)
+	require.NoError(t, err)
+
+	principals, err := userClient.Management.Principal.List(nil)
+	require.NoError(t, err)
+
+	userPrincipalID := ""
+	groupPrincipalIDs := []string{}
+
+	for _, principal := range principals.Data {
+		if principal.Me {
+			userPrincipalID = principal.ID
+		}
+
+		if principal.MemberOf && principal.PrincipalType == groupPrincipal {
+			groupPrincipalIDs = append(groupPrincipalIDs, principal.ID)
+		}
+	}
+
+	logrus.Infof("Verifying %s is a member of group %s...", userPrincipalID, keycloak.Group)
+	require.True(t, strings.HasPrefix(userPrincipalID, authProvider+"_user://"), "User principal %s is not from %s", userPrincipalID, authProvider)
+	require.Contains(t, groupPrincipalIDs, authProvider+"_group://"+keycloak.Group)
+}
+
+// newLoginClient returns an HTTP client that keeps the cookies of the login flow and stops at the redirects back to Rancher, so
+// the code or token Rancher is given can be read from the response.
+func newLoginClient(rancherConfig *rancher.Config) (*http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	insecure := rancherConfig.Insecure != nil && *rancherConfig.Insecure
+
+	return &http.Client{
+		Jar: jar,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host == rancherConfig.Host {
+				return http.ErrUseLastResponse
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// oidcLogin requests an authorization code for the Rancher redirect URL by submitting the Keycloak login form, and exchanges the
+// code for a Rancher token through the login action of the auth provider.
+func oidcLogin(httpClient *http.Client, rancherConfig *rancher.Config, server *keycloak.Server, authProvider string) (string, error) {
+	oidcConfig := server.OIDCConfig()
+
+	query := url.Values{
+		"client_id":     {oidcConfig.ClientID},
+		"redirect_uri":  {oidc.RancherURL(rancherConfig)},
+		"response_type": {"code"},
+		"scope":         {oidcScopes},
+		"state":         {namegen.RandStringLower(16)},
+	}
+
+	resp, err := submitKeycloakLogin(httpClient, oidcConfig.AuthEndpoint+"?"+query.Encode(), server)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("Keycloak did not redirect to Rancher after login: %v", resp.Status)
+	}
+
+	code := location.Query().Get("code")
+	if code == "" {
+		return "", fmt.Errorf("Keycloak did not return an authorization code: %v", location.Query().Get("error_description"))
+	}
+
+	tokenResp := struct {
+		Token string `json:"token"`
+	}{}
+
+	err = rancherLogin(httpClient, rancherConfig.Host, authProvider, map[string]string{"code": code, "responseType": responseTypeJSON}, &tokenResp)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenResp.Token, nil
+}
+
+// samlLogin starts the SAML login of Rancher, submits the Keycloak login form and posts the assertion to the assertion consumer
+// service of Rancher, which sets the token of the user as a cookie.
+func samlLogin(httpClient *http.Client, rancherHost string, server *keycloak.Server) (string, error) {
+	loginResp := struct {
+		IdpRedirectURL string `json:"idpRedirectUrl"`
+	}{}
+
+	err := rancherLogin(httpClient, rancherHost, authproviders.Keycloak, map[string]string{"finalRedirectUrl": "https://" + rancherHost + finalRedirectPath}, &loginResp)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := submitKeycloakLogin(httpClient, loginResp.IdpRedirectURL, server)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	acsURL, values, err := parseForm(string(body), anyFormPattern)
+	if err != nil {
+		return "", err
+	}
+
+	acsResp, err := httpClient.PostForm(acsURL, values)
+	if err != nil {
+		return "", err
+	}
+	defer acsResp.Body.Close()
+
+	for _, cookie := range acsResp.Cookies() {
+		if cookie.Name == tokenCookie {
+			return cookie.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("Rancher did not set a token after the SAML assertion: %v", acsResp.Status)
+}
+
+// submitKeycloakLogin opens the Keycloak login page and submits the credentials of the test user, returning the response of the
+// realm to the login.
+func submitKeycloakLogin(httpClient *http.Client, loginURL string, server *keycloak.Server) (*http.Response, error) {
+	resp, err := httpClient.Get(loginURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	action, values, err := parseForm(string(body), loginFormPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	values.Set("username", server.Username)
+	values.Set("password", server.Password)
+
+	return httpClient.PostForm(action, values)
+}
+
+// rancherLogin posts the login action of the auth provider to the v3-public API and decodes the response into output.
+func rancherLogin(httpClient *http.Client, rancherHost, authProvider string, input map[string]string, output any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	loginURL := "https://" + rancherHost + loginEndpoints[authProvider] + "?action=" + loginAction
+
+	resp, err := httpClient.Post(loginURL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Rancher login through %s failed: %v", authProvider, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(output)
+}
+
+// parseForm returns the action and the values of the inputs of the first form in the page matching the pattern.
+func parseForm(page string, formPattern *regexp.Regexp) (string, url.Values, error) {
+	form := formPattern.FindString(page)
+	if form == "" {
+		return "", nil, fmt.Errorf("No form found in page: %v", formPattern.String())
+	}
+
+	action := actionPattern.FindStringSubmatch(form)
+	if action == nil {
+		return "", nil, fmt.Errorf("No action found in form: %v", formPattern.String())
+	}
+
+	values := url.Values{}
+	for _, input := range inputPattern.FindAllStringSubmatch(form, -1) {
+		values.Set(html.UnescapeString(input[1]), html.UnescapeString(input[2]))
+	}
+
+	return html.UnescapeString(action[1]), values, nil
+}
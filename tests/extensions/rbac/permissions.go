@@ -0,0 +1,379 @@
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rancher/norman/types"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	password "github.com/rancher/shepherd/extensions/users/passwordgenerator"
+	"github.com/rancher/shepherd/extensions/workloads"
+	"github.com/rancher/shepherd/pkg/clientbase"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tests/actions/namespaces"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	setrbac "github.com/rancher/tfp-automation/framework/set/rbac"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	clusterRepoSteveType = "catalog.cattle.io.clusterrepo"
+	globalRoleSteveType  = "management.cattle.io.globalrole"
+
+	clusterIDFilter  = "clusterId"
+	create           = "create"
+	defaultNamespace = "default"
+	list             = "list"
+	clusterRepoURL   = "https://charts.rancher.io"
+	nginxImage       = "nginx"
+	permissionPrefix = "tfp-rbac"
+
+	noMethodError      = "has no method"
+	notCreatableError  = "is not creatable"
+	unknownSchemaError = "Unknown schema type"
+)
+
+type scope string
+
+const (
+	// clusterScope is the downstream cluster, without a namespace.
+	clusterScope scope = "cluster"
+	// projectNamespace is a namespace in the project the role is bound to.
+	projectNamespace scope = "project-namespace"
+	// otherNamespace is the default namespace, which belongs to the Default project of the cluster.
+	otherNamespace scope = "other-namespace"
+	// globalScope is the Rancher server.
+	globalScope scope = "global"
+)
+
+type permission struct {
+	verb     string
+	resource string
+	scope    scope
+	allowed  bool
+}
+
+// permissionMatrix is the allow/deny matrix of each RBAC role. A list is allowed when it returns resources, as Steve filters out the
+// resources the user cannot access instead of failing. A create is allowed when the resource is created and denied when it is forbidden.
+var permissionMatrix = map[config.Role][]permission{
+	config.ClusterOwner: {
+		{verb: list, resource: stevetypes.Node, scope: clusterScope, allowed: true},
+		{verb: list, resource: stevetypes.ConfigMap, scope: otherNamespace, allowed: true},
+		{verb: create, resource: stevetypes.Deployment, scope: otherNamespace, allowed: true},
+		{verb: create, resource: globalRoleSteveType, scope: globalScope, allowed: false},
+	},
+	config.ProjectOwner: {
+		{verb: list, resource: stevetypes.ConfigMap, scope: projectNamespace, allowed: true},
+		{verb: create, resource: stevetypes.Deployment, scope: projectNamespace, allowed: true},
+		{verb: list, resource: stevetypes.Node, scope: clusterScope, allowed: false},
+		{verb: list, resource: stevetypes.ConfigMap, scope: otherNamespace, allowed: false},
+		{verb: create, resource: stevetypes.Deployment, scope: otherNamespace, allowed: false},
+		{verb: create, resource: globalRoleSteveType, scope: globalScope, allowed: false},
+	},
+}
+
+// VerifyPermissions is a function that will log in as the user bound to the RBAC role and verify every entry of the permission matrix
+// of the role with the token of the user. Each entry is reported as its own subtest. For custom roles, the matrix of every role template
+// and global role is built from its rules and checked with the token of the user bound to it.
+func VerifyPermissions(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig, rbacRole config.Role) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	if rbacRole == config.CustomRoles {
+		verifyCustomRolePermissions(t, client, terraformConfig, clusterID)
+		return
+	}
+
+	permissions, ok := permissionMatrix[rbacRole]
+	require.Truef(t, ok, "No permission matrix for RBAC role %s", rbacRole)
+
+	user, projectID, err := boundUser(client, clusterID, rbacRole)
+	require.NoError(t, err)
+
+	runPermissions(t, client, clusterID, string(rbacRole), user, projectID, permissions)
+}
+
+// runPermissions logs in as the user and verifies every entry of the permissions with the token of the user, each as its own subtest.
+// Entries in the project namespace run in a new namespace of the project, which is seeded with the listed resources by the admin so
+// that a list only comes back empty when it is denied.
+func runPermissions(t *testing.T, client *rancher.Client, clusterID, roleName string, user *management.User, projectID string,
+	permissions []permission) {
+	userClient, err := loginAsUser(client, user)
+	require.NoError(t, err)
+
+	namespaceName := ""
+	if projectID != "" {
+		namespaceName, err = createProjectNamespace(client, projectID)
+		require.NoError(t, err)
+
+		err = seedProjectNamespace(client, clusterID, namespaceName, permissions)
+		require.NoError(t, err)
+	}
+
+	for _, entry := range permissions {
+		testName := fmt.Sprintf("%s_%s_%s", entry.verb, entry.resource, entry.scope)
+
+		t.Run(testName, func(t *testing.T) {
+			namespace := ""
+			switch entry.scope {
+			case projectNamespace:
+				require.NotEmpty(t, namespaceName, "Role %s is not bound to a project", roleName)
+				namespace = namespaceName
+			case otherNamespace:
+				namespace = defaultNamespace
+			}
+
+			logrus.Infof("Verifying %s %s %s %s in %s...", roleName, expectation(entry.allowed), entry.verb, entry.resource, entry.scope)
+
+			allowed, err := isAllowed(userClient, clusterID, namespace, entry)
+			require.NoError(t, err)
+			require.Equal(t, entry.allowed, allowed, "%s %s %s %s in %s", roleName, expectation(entry.allowed), entry.verb, entry.resource, entry.scope)
+		})
+	}
+}
+
+// boundUser returns the user of the role template binding created for the RBAC role and, for project roles, the ID of its project.
+func boundUser(client *rancher.Client, clusterID string, rbacRole config.Role) (*management.User, string, error) {
+	var userID, projectID string
+
+	switch rbacRole {
+	case config.ClusterOwner:
+		bindings, err := client.Management.ClusterRoleTemplateBinding.ListAll(&types.ListOpts{
+			Filters: map[string]any{
+				clusterIDFilter: clusterID,
+				name:            setrbac.ClusterRoleTemplateBindingName,
+				roleTemplateID:  string(rbacRole),
+			},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(bindings.Data) != 1 {
+			return nil, "", fmt.Errorf("Expected one cluster role template binding for RBAC role %s, found: %v", rbacRole, len(bindings.Data))
+		}
+
+		userID = bindings.Data[0].UserID
+	case config.ProjectOwner:
+		bindings, err := client.Management.ProjectRoleTemplateBinding.ListAll(&types.ListOpts{
+			Filters: map[string]any{
+				name:           setrbac.ProjectRoleTemplateBindingName,
+				roleTemplateID: string(rbacRole),
+			},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, binding := range bindings.Data {
+			if strings.HasPrefix(binding.ProjectID, clusterID+":") {
+				userID, projectID = binding.UserID, binding.ProjectID
+			}
+		}
+
+		if userID == "" {
+			return nil, "", fmt.Errorf("No project role template binding found for RBAC role %s in cluster: %v", rbacRole, clusterID)
+		}
+	default:
+		return nil, "", fmt.Errorf("Unsupported RBAC role for permission checks: %v", rbacRole)
+	}
+
+	user, err := client.Management.User.ByID(userID)
+
+	return user, projectID, err
+}
+
+// loginAsUser resets the password of the user, as Terraform does not expose it, and returns a client with the token of the user.
+func loginAsUser(client *rancher.Client, user *management.User) (*rancher.Client, error) {
+	newPassword := password.GenerateUserPassword("testpass")
+
+	_, err := client.Management.User.ActionSetpassword(user, &management.SetPasswordInput{NewPassword: newPassword})
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = newPassword
+
+	return client.AsUser(user)
+}
+
+// createProjectNamespace creates a namespace in the given project as the admin user and returns its name.
+func createProjectNamespace(client *rancher.Client, projectID string) (string, error) {
+	project, err := client.Management.Project.ByID(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	namespaceName := namegen.AppendRandomString(permissionPrefix)
+
+	_, err = namespaces.CreateNamespace(client, namespaceName, "", nil, nil, project)
+	if err != nil {
+		return "", err
+	}
+
+	return namespaceName, nil
+}
+
+// seedProjectNamespace creates one of every resource listed in the project namespace by the permissions as the admin user.
+func seedProjectNamespace(client *rancher.Client, clusterID, namespace string, permissions []permission) error {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return err
+	}
+
+	seeded := map[string]bool{}
+
+	for _, entry := range permissions {
+		if entry.verb != list || entry.scope != projectNamespace || seeded[entry.resource] {
+			continue
+		}
+
+		resource, err := newResource(entry.resource, namespace)
+		if err != nil {
+			return err
+		}
+
+		_, err = steveClient.SteveType(entry.resource).Create(resource)
+		if err != nil {
+			return err
+		}
+
+		seeded[entry.resource] = true
+	}
+
+	return nil
+}
+
+// isAllowed runs the entry of the permission matrix with the client of the user and returns whether it was allowed. Created resources
+// are deleted right away.
+func isAllowed(userClient *rancher.Client, clusterID, namespace string, entry permission) (bool, error) {
+	steveClient := userClient.Steve
+	if entry.scope != globalScope {
+		var err error
+
+		steveClient, err = userClient.Steve.ProxyDownstream(clusterID)
+		if isForbidden(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+
+	switch entry.verb {
+	case list:
+		var resources *steveV1.SteveCollection
+		var err error
+
+		if namespace != "" {
+			resources, err = steveClient.SteveType(entry.resource).NamespacedSteveClient(namespace).List(nil)
+		} else {
+			resources, err = steveClient.SteveType(entry.resource).List(nil)
+		}
+
+		if isForbidden(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		return len(resources.Data) > 0, nil
+	case create:
+		resource, err := newResource(entry.resource, namespace)
+		if err != nil {
+			return false, err
+		}
+
+		resp, err := steveClient.SteveType(entry.resource).Create(resource)
+		if isForbidden(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		return true, steveClient.SteveType(entry.resource).Delete(resp)
+	}
+
+	return false, fmt.Errorf("Unsupported verb for RBAC permissions: %v", entry.verb)
+}
+
+// newResource returns a minimal resource of the given type to create in the namespace.
+func newResource(resource, namespace string) (any, error) {
+	resourceName := namegen.AppendRandomString(permissionPrefix)
+
+	switch resource {
+	case stevetypes.Deployment:
+		containerTemplate := workloads.NewContainer(permissionPrefix, nginxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+		podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+
+		return workloads.NewDeploymentTemplate(resourceName, namespace, podTemplate, true, nil), nil
+	case stevetypes.ConfigMap:
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: namespace,
+			},
+		}, nil
+	case stevetypes.Secret:
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}, nil
+	case clusterRepoSteveType:
+		return map[string]any{
+			"type": clusterRepoSteveType,
+			"metadata": map[string]any{
+				"name": resourceName,
+			},
+			"spec": map[string]any{
+				"url": clusterRepoURL,
+			},
+		}, nil
+	case globalRoleSteveType:
+		return map[string]any{
+			"type": globalRoleSteveType,
+			"metadata": map[string]any{
+				"name": resourceName,
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported resource for RBAC permissions: %v", resource)
+}
+
+// isForbidden returns whether the error is a forbidden response of the Rancher API. Steve omits the schemas and methods the user has
+// no access to, so the client errors of a missing schema or method are denials as well.
+func isForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiError *clientbase.APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusForbidden
+	}
+
+	return strings.HasPrefix(err.Error(), unknownSchemaError) || strings.HasSuffix(err.Error(), notCreatableError) ||
+		strings.Contains(err.Error(), noMethodError)
+}
+
+// expectation returns how an entry of the permission matrix reads in logs and failures.
+func expectation(allowed bool) string {
+	if allowed {
+		return "can"
+	}
+
+	return "cannot"
+}
@@ -14,7 +14,10 @@ func SupportedAuthProviders(terraformConfig *config.TerraformConfig, terraformOp
 	supportedAuthProviders := []string{
 		authproviders.AD,
 		authproviders.AzureAD,
+		authproviders.GenericOIDC,
 		authproviders.GitHub,
+		authproviders.Keycloak,
+		authproviders.KeycloakOIDC,
 		authproviders.Okta,
 		authproviders.OpenLDAP,
 	}
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/set/resources/chartrepo"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChartRepoTestSuite struct {
+	suite.Suite
+	terraformConfig  *config.TerraformConfig
+	terratestConfig  *config.TerratestConfig
+	cattleConfig     map[string]any
+	rancherConfig    *rancher.Config
+	terraformOptions *terraform.Options
+}
+
+func (i *ChartRepoTestSuite) TestCreateChartRepo() {
+	i.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	i.rancherConfig, i.terraformConfig, i.terratestConfig, _ = config.LoadTFPConfigs(i.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.ChartRepoKeyPath, i.terratestConfig.PathToRepo, i.terraformConfig.Provider)
+	terraformOptions := framework.Setup(i.T(), i.terraformConfig, i.terratestConfig, keyPath)
+	i.terraformOptions = terraformOptions
+
+	server, err := chartrepo.CreateMainTF(i.T(), i.terraformOptions, keyPath, i.rancherConfig, i.terraformConfig, i.terratestConfig)
+	require.NoError(i.T(), err)
+
+	logrus.Infof("Chart repository Helm URL: %s", server.HelmURL)
+	logrus.Infof("Chart repository git URL: %s", server.GitURL)
+	logrus.Infof("Chart repository CA:\n%s", server.CABundle)
+}
+
+func TestChartRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ChartRepoTestSuite))
+}
@@ -0,0 +1,131 @@
+package apps
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// defaultApps are installed when the config does not set any apps. The rancher-logging CRDs are installed first.
+var defaultApps = []map[string]any{
+	{
+		"name":      "rancher-logging-crd",
+		"namespace": "cattle-logging-system",
+	},
+	{
+		"name":      "rancher-logging",
+		"namespace": "cattle-logging-system",
+	},
+}
+
+type AppsTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (a *AppsTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	a.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(a.T(), err)
+
+	a.client = client
+
+	a.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	a.rancherConfig, a.terraformConfig, a.terratestConfig, _ = config.LoadTFPConfigs(a.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, a.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(a.T(), a.terraformConfig, a.terratestConfig, keyPath)
+	a.terraformOptions = terraformOptions
+}
+
+func (a *AppsTestSuite) TestTfpApps() {
+	var err error
+	var testUser, testPassword string
+
+	a.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(a.client)
+	require.NoError(a.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		nodeRoles []config.Nodepool
+	}{
+		{"Apps_Install", nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(a.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{a.cattleConfig})
+		require.NoError(a.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(a.T(), err)
+
+		if len(a.terraformConfig.Apps) == 0 {
+			_, err = operations.ReplaceValue([]string{"terraform", "apps"}, defaultApps, configMap[0])
+			require.NoError(a.T(), err)
+		}
+
+		provisioning.GetK8sVersion(a.T(), a.client, a.terratestConfig, a.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		a.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, a.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(a.T(), a.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(a.T(), a.client)
+			require.NoError(a.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(a.T(), a.client, a.standardUserClient, rancher, terraform, terratest, testUser, testPassword, a.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(a.T(), adminClient, clusterIDs)
+
+			provisioning.VerifyApps(a.T(), adminClient, terraform)
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if a.terratestConfig.LocalQaseReporting {
+		results.ReportTest(a.terratestConfig)
+	}
+}
+
+func TestTfpAppsTestSuite(t *testing.T) {
+	suite.Run(t, new(AppsTestSuite))
+}
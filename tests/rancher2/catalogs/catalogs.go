@@ -0,0 +1,162 @@
+package catalogs
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	catalogv1 "github.com/rancher/rancher/pkg/apis/catalog.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/clients/rancher/catalog"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/charts"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/pkg/api/steve/catalog/types"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/resources/chartrepo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const defaultNamespace = "default"
+
+// ApplyCatalog is a function that will run terraform apply to add the standalone chart repository as a catalog of the given repo type.
+func ApplyCatalog(t *testing.T, rancherConfig *rancher.Config, terraformOptions *terraform.Options, testUser, testPassword string,
+	configMap []map[string]any, server *chartrepo.Server, repoType string, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	err := framework.Catalog(rancherConfig, testUser, testPassword, configMap, server, repoType, newFile, rootBody, file)
+	require.NoError(t, err)
+
+	terraform.InitAndApply(t, terraformOptions)
+}
+
+// VerifyCatalog waits for the catalog to download the index of the chart repository, verifies the chart is listed, and installs and
+// uninstalls it in the local cluster.
+func VerifyCatalog(t *testing.T, client *rancher.Client, catalogName, releaseName string) {
+	logrus.Infof("Waiting for catalog %s to be downloaded...", catalogName)
+	err := waitForDownload(client, catalogName, time.Time{})
+	require.NoError(t, err)
+
+	versions, err := client.Catalog.GetListChartVersions(chartrepo.ChartName, catalogName)
+	require.NoError(t, err)
+	require.True(t, slices.Contains(versions, chartrepo.ChartVersion), "Chart %s %s is not listed in catalog %s", chartrepo.ChartName,
+		chartrepo.ChartVersion, catalogName)
+
+	logrus.Infof("Installing chart %s %s from catalog %s...", chartrepo.ChartName, chartrepo.ChartVersion, catalogName)
+	err = client.Catalog.InstallChart(&types.ChartInstallAction{
+		Namespace: defaultNamespace,
+		Charts: []types.ChartInstall{
+			{
+				ChartName:   chartrepo.ChartName,
+				Version:     chartrepo.ChartVersion,
+				ReleaseName: releaseName,
+			},
+		},
+	}, catalogName)
+	require.NoError(t, err)
+
+	err = charts.WaitChartInstall(client.Catalog, defaultNamespace, releaseName)
+	require.NoError(t, err)
+
+	logrus.Infof("Uninstalling chart %s...", releaseName)
+	err = client.Catalog.UninstallChart(releaseName, defaultNamespace, &types.ChartUninstallAction{})
+	require.NoError(t, err)
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), 5*time.Second, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.Catalog.Apps(defaultNamespace).Get(ctx, releaseName, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), nil
+	})
+	require.NoError(t, err)
+}
+
+// VerifyCatalogFails forces the catalog to refresh its index and waits for the download to fail, as it does once the credentials of
+// the chart repository are rotated.
+func VerifyCatalogFails(t *testing.T, client *rancher.Client, catalogName string) {
+	_, err := forceUpdate(client, catalogName)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for catalog %s to fail to download...", catalogName)
+	err = kwait.PollUntilContextTimeout(context.TODO(), 5*time.Second, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, clusterRepo, err := getClusterRepo(client, catalogName)
+		if err != nil {
+			return false, nil
+		}
+
+		return downloadedStatus(clusterRepo) == corev1.ConditionFalse, nil
+	})
+	require.NoError(t, err)
+}
+
+// VerifyCatalogRecovers forces the catalog to refresh its index and waits for it to be downloaded again with the updated credentials.
+func VerifyCatalogRecovers(t *testing.T, client *rancher.Client, catalogName string) {
+	forceUpdateTime, err := forceUpdate(client, catalogName)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for catalog %s to recover...", catalogName)
+	err = waitForDownload(client, catalogName, forceUpdateTime)
+	require.NoError(t, err)
+}
+
+// forceUpdate sets the force update time of the catalog so Rancher downloads its index again, and returns the time it was set to.
+func forceUpdate(client *rancher.Client, catalogName string) (time.Time, error) {
+	clusterRepoResp, clusterRepo, err := getClusterRepo(client, catalogName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	forceUpdateTime := metav1.Now().Rfc3339Copy()
+	clusterRepo.Spec.ForceUpdate = &forceUpdateTime
+
+	_, err = client.Steve.SteveType(catalog.ClusterRepoSteveResourceType).Update(clusterRepoResp, clusterRepo)
+
+	return forceUpdateTime.Time, err
+}
+
+// waitForDownload waits for the catalog index to be downloaded at or after the given time.
+func waitForDownload(client *rancher.Client, catalogName string, after time.Time) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 5*time.Second, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, clusterRepo, err := getClusterRepo(client, catalogName)
+		if err != nil {
+			return false, nil
+		}
+
+		if downloadedStatus(clusterRepo) != corev1.ConditionTrue {
+			return false, nil
+		}
+
+		return !clusterRepo.Status.DownloadTime.Time.Before(after), nil
+	})
+}
+
+// getClusterRepo returns the cluster repo of the catalog along with its steve object.
+func getClusterRepo(client *rancher.Client, catalogName string) (*steveV1.SteveAPIObject, *catalogv1.ClusterRepo, error) {
+	clusterRepoResp, err := client.Steve.SteveType(catalog.ClusterRepoSteveResourceType).ByID(catalogName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterRepo := &catalogv1.ClusterRepo{}
+	if err := steveV1.ConvertToK8sType(clusterRepoResp.JSONResp, clusterRepo); err != nil {
+		return nil, nil, err
+	}
+
+	return clusterRepoResp, clusterRepo, nil
+}
+
+// downloadedStatus returns the status of the downloaded condition of the cluster repo, which is empty until the condition is set.
+func downloadedStatus(clusterRepo *catalogv1.ClusterRepo) corev1.ConditionStatus {
+	for _, condition := range clusterRepo.Status.Conditions {
+		if condition.Type == string(catalogv1.RepoDownloaded) {
+			return condition.Status
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,131 @@
+package catalogs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/chartrepo"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	setCatalogs "github.com/rancher/tfp-automation/framework/set/resources/rancher2/catalogs"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogsTestSuite struct {
+	suite.Suite
+	client                    *rancher.Client
+	session                   *session.Session
+	cattleConfig              map[string]any
+	rancherConfig             *rancher.Config
+	terraformConfig           *config.TerraformConfig
+	terratestConfig           *config.TerratestConfig
+	terraformOptions          *terraform.Options
+	chartRepoTerraformOptions *terraform.Options
+	chartRepo                 *chartrepo.Server
+	chartRepoPassword         string
+}
+
+func (c *CatalogsTestSuite) TearDownSuite() {
+	_, keyPath := rancher2.SetKeyPath(keypath.ChartRepoKeyPath, c.terratestConfig.PathToRepo, c.terraformConfig.Provider)
+	cleanup.Cleanup(c.T(), c.chartRepoTerraformOptions, keyPath)
+}
+
+func (c *CatalogsTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	c.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(c.T(), err)
+
+	c.client = client
+
+	c.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	c.rancherConfig, c.terraformConfig, c.terratestConfig, _ = config.LoadTFPConfigs(c.cattleConfig)
+
+	_, chartRepoKeyPath := rancher2.SetKeyPath(keypath.ChartRepoKeyPath, c.terratestConfig.PathToRepo, c.terraformConfig.Provider)
+	c.chartRepoTerraformOptions = framework.Setup(c.T(), c.terraformConfig, c.terratestConfig, chartRepoKeyPath)
+
+	c.chartRepo, err = chartrepo.CreateMainTF(c.T(), c.chartRepoTerraformOptions, chartRepoKeyPath, c.rancherConfig, c.terraformConfig, c.terratestConfig)
+	require.NoError(c.T(), err)
+
+	c.chartRepoPassword = c.terraformConfig.StandaloneChartRepo.Password
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, c.terratestConfig.PathToRepo, "")
+	c.terraformOptions = framework.Setup(c.T(), c.terraformConfig, c.terratestConfig, keyPath)
+}
+
+func (c *CatalogsTestSuite) TestTfpCatalogs() {
+	tests := []struct {
+		name     string
+		repoType string
+	}{
+		{"Catalog_HTTP_Repo", setCatalogs.HTTP},
+		{"Catalog_Git_Repo", setCatalogs.Git},
+	}
+
+	testUser, testPassword := configs.CreateTestCredentials()
+
+	for _, tt := range tests {
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{c.cattleConfig})
+		require.NoError(c.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "standaloneChartRepo", "password"}, c.chartRepoPassword, configMap[0])
+		require.NoError(c.T(), err)
+
+		rancherConfig, terraformConfig, _, _ := config.LoadTFPConfigs(configMap[0])
+
+		catalogName := setCatalogs.CatalogName(terraformConfig, tt.repoType)
+
+		c.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, c.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(c.T(), c.terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeMainTF(c.terratestConfig)
+			defer file.Close()
+
+			ApplyCatalog(c.T(), rancherConfig, c.terraformOptions, testUser, testPassword, configMap, c.chartRepo, tt.repoType, newFile, rootBody, file)
+
+			VerifyCatalog(c.T(), c.client, catalogName, terraformConfig.ResourcePrefix)
+
+			c.chartRepoPassword = namegen.AppendRandomString(configs.TestPassword)
+
+			logrus.Infof("Rotating the credentials of the chart repository...")
+			err := chartrepo.RotateCredentials(terraformConfig, c.chartRepo.Host, terraformConfig.StandaloneChartRepo.Username, c.chartRepoPassword)
+			require.NoError(c.T(), err)
+
+			VerifyCatalogFails(c.T(), c.client, catalogName)
+
+			_, err = operations.ReplaceValue([]string{"terraform", "standaloneChartRepo", "password"}, c.chartRepoPassword, configMap[0])
+			require.NoError(c.T(), err)
+
+			newFile, rootBody, file = rancher2.InitializeMainTF(c.terratestConfig)
+			defer file.Close()
+
+			ApplyCatalog(c.T(), rancherConfig, c.terraformOptions, testUser, testPassword, configMap, c.chartRepo, tt.repoType, newFile, rootBody, file)
+
+			VerifyCatalogRecovers(c.T(), c.client, catalogName)
+		})
+	}
+
+	if c.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(c.terratestConfig)
+	}
+}
+
+func TestCatalogsTestSuite(t *testing.T) {
+	suite.Run(t, new(CatalogsTestSuite))
+}
@@ -0,0 +1,208 @@
+package certrotation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/clusters"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/workloads"
+	"github.com/rancher/shepherd/extensions/workloads/pods"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	deploy "github.com/rancher/tests/actions/workloads/deployment"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	apiServerPort    = "6443"
+	containerImage   = "nginx"
+	containerName    = "nginx"
+	defaultNamespace = "default"
+	isCattleLabeled  = true
+	rke1APIServer    = "kube-apiserver"
+	rke2K3sAPIServer = "api-server"
+	rotationWorkload = "wload-cert-rotation"
+)
+
+// RotateCertificates creates a workload, rotates the certificates of the cluster through the rotate_certificates block and verifies
+// the kube-apiserver serving certificates were reissued while the workload kept running. If no services are given, the certificates
+// of every service are rotated.
+func RotateCertificates(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, services []string) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	containerTemplate := workloads.NewContainer(containerName, containerImage, corev1.PullAlways, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+	deployment := workloads.NewDeploymentTemplate(namegen.AppendRandomString(rotationWorkload), defaultNamespace, podTemplate, isCattleLabeled, nil)
+
+	deploymentResp, err := steveclient.SteveType(stevetypes.Deployment).Create(deployment)
+	require.NoError(t, err)
+
+	err = deploy.VerifyDeployment(steveclient, deploymentResp)
+	require.NoError(t, err)
+
+	initialCertificates, err := getServingCertificates(client, clusterID)
+	require.NoError(t, err)
+	require.NotEmpty(t, initialCertificates)
+
+	generation := int64(1)
+	if terratestConfig.CertRotationInput != nil {
+		generation = terratestConfig.CertRotationInput.Generation + 1
+	}
+
+	certRotationInput := map[string]any{
+		"generation": generation,
+		"services":   services,
+	}
+
+	_, err = operations.ReplaceValue([]string{"terratest", "certRotationInput"}, certRotationInput, configMap[0])
+	require.NoError(t, err)
+
+	logrus.Infof("Rotating certificates of cluster %s with generation %d...", terraformConfig.ResourcePrefix, generation)
+	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+
+	terraform.Apply(t, terraformOptions)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+
+	err = verifyCertificatesRotated(client, clusterID, initialCertificates)
+	require.NoError(t, err)
+
+	podErrors := pods.StatusPods(client, clusterID)
+	assert.Empty(t, podErrors)
+
+	logrus.Infof("Verifying deployment %s is still running...", deploymentResp.Name)
+	err = deploy.VerifyDeployment(steveclient, deploymentResp)
+	require.NoError(t, err)
+
+	err = steveclient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	require.NoError(t, err)
+}
+
+// APIServerService returns the name the kube-apiserver service is rotated by for the given module.
+func APIServerService(module string) string {
+	if strings.Contains(module, clustertypes.RKE1) {
+		return rke1APIServer
+	}
+
+	return rke2K3sAPIServer
+}
+
+// verifyCertificatesRotated waits for the kube-apiserver serving certificate of every control plane node to be reissued with a new
+// serial and a later expiry.
+func verifyCertificatesRotated(client *rancher.Client, clusterID string, initialCertificates map[string]*x509.Certificate) error {
+	var lastErr error
+
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		rotatedCertificates, err := getServingCertificates(client, clusterID)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+
+		for node, initialCertificate := range initialCertificates {
+			rotatedCertificate, ok := rotatedCertificates[node]
+			if !ok {
+				lastErr = fmt.Errorf("No serving certificate found on node %s after rotation", node)
+				return false, nil
+			}
+
+			if rotatedCertificate.SerialNumber.Cmp(initialCertificate.SerialNumber) == 0 {
+				lastErr = fmt.Errorf("Serving certificate serial of node %s did not change: %s", node, rotatedCertificate.SerialNumber)
+				return false, nil
+			}
+
+			if !rotatedCertificate.NotAfter.After(initialCertificate.NotAfter) {
+				lastErr = fmt.Errorf("Serving certificate expiry of node %s did not change: %s", node, rotatedCertificate.NotAfter)
+				return false, nil
+			}
+
+			logrus.Infof("Serving certificate of node %s was rotated (serial %s, expires %s)", node, rotatedCertificate.SerialNumber, rotatedCertificate.NotAfter)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+
+	return nil
+}
+
+// getServingCertificates returns the kube-apiserver serving certificate of every control plane node of the cluster, keyed by node name.
+func getServingCertificates(client *rancher.Client, clusterID string) (map[string]*x509.Certificate, error) {
+	nodes, err := client.Management.Node.ListAll(&types.ListOpts{
+		Filters: map[string]any{
+			"clusterId": clusterID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := map[string]*x509.Certificate{}
+
+	for _, node := range nodes.Data {
+		if !node.ControlPlane {
+			continue
+		}
+
+		address := node.ExternalIPAddress
+		if address == "" {
+			address = node.IPAddress
+		}
+
+		certificate, err := getServingCertificate(address)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates[node.NodeName] = certificate
+	}
+
+	return certificates, nil
+}
+
+// getServingCertificate returns the certificate the kube-apiserver serves on the given address.
+func getServingCertificate(address string) (*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: timeouts.TenSecondTimeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(address, apiServerPort), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	peerCertificates := conn.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, fmt.Errorf("No serving certificate found on %s", address)
+	}
+
+	return peerCertificates[0], nil
+}
@@ -0,0 +1,113 @@
+package certrotation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertRotationTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (c *CertRotationTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	c.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(c.T(), err)
+
+	c.client = client
+
+	c.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	c.rancherConfig, c.terraformConfig, c.terratestConfig, _ = config.LoadTFPConfigs(c.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, c.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(c.T(), c.terraformConfig, c.terratestConfig, keyPath)
+	c.terraformOptions = terraformOptions
+}
+
+func (c *CertRotationTestSuite) TestTfpCertRotation() {
+	var err error
+	var testUser, testPassword string
+
+	c.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(c.client)
+	require.NoError(c.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name          string
+		nodeRoles     []config.Nodepool
+		apiServerOnly bool
+	}{
+		{"Cert_Rotation_All_Services", nodeRolesDedicated, false},
+		{"Cert_Rotation_API_Server", nodeRolesDedicated, true},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(c.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{c.cattleConfig})
+		require.NoError(c.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(c.T(), err)
+
+		provisioning.GetK8sVersion(c.T(), c.client, c.terratestConfig, c.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		var services []string
+		if tt.apiServerOnly {
+			services = []string{APIServerService(terraform.Module)}
+		}
+
+		c.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, c.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(c.T(), c.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(c.T(), c.client)
+			require.NoError(c.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(c.T(), c.client, c.standardUserClient, rancher, terraform, terratest, testUser, testPassword, c.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(c.T(), adminClient, clusterIDs)
+
+			RotateCertificates(c.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, c.terraformOptions, configMap, newFile, rootBody, file, services)
+			provisioning.VerifyClustersState(c.T(), adminClient, clusterIDs)
+		})
+	}
+
+	if c.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(c.terratestConfig)
+	}
+}
+
+func TestTfpCertRotationTestSuite(t *testing.T) {
+	suite.Run(t, new(CertRotationTestSuite))
+}
@@ -0,0 +1,221 @@
+package fleet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	bundleSteveType       = "fleet.cattle.io.bundle"
+	clusterGroupSteveType = "fleet.cattle.io.clustergroup"
+	gitRepoSteveType      = "fleet.cattle.io.gitrepo"
+
+	fleetDefaultNamespace = "fleet-default"
+	clusterNameLabel      = "management.cattle.io/cluster-name"
+	repoNameLabel         = "fleet.cattle.io/repo-name"
+	pollingInterval       = "15s"
+
+	fleetPrefix      = "tfp-fleet"
+	initialRevision  = "1"
+	updatedRevision  = "2"
+	revisionDataName = "revision"
+)
+
+type fleetSummary struct {
+	DesiredReady int `json:"desiredReady"`
+	Ready        int `json:"ready"`
+}
+
+type fleetStatus struct {
+	Commit  string       `json:"commit"`
+	Summary fleetSummary `json:"summary"`
+}
+
+// VerifyFleetGitRepo serves a fixture repo from the test host and deploys it through a Fleet GitRepo targeting a cluster group of
+// the given clusters. It verifies the bundles become ready and the resources appear downstream, then commits a new revision and
+// verifies the update rolls out.
+func VerifyFleetGitRepo(t *testing.T, client *rancher.Client, terratestConfig *config.TerratestConfig, clusterIDs []string) {
+	name := namegen.AppendRandomString(fleetPrefix)
+	namespace := name
+
+	server := startGitServer(t, client.RancherConfig.Host, terratestConfig, namespace)
+	defer server.stop()
+
+	commit := server.commitRevision(t, name, initialRevision)
+
+	clusterGroup, err := createClusterGroup(client, name, clusterIDs)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, client.Steve.SteveType(clusterGroupSteveType).Delete(clusterGroup))
+	}()
+
+	gitRepo, err := createGitRepo(client, name, server.repoURL)
+	require.NoError(t, err)
+
+	defer func() {
+		logrus.Infof("Deleting GitRepo %s...", name)
+		require.NoError(t, client.Steve.SteveType(gitRepoSteveType).Delete(gitRepo))
+	}()
+
+	verifyRollout(t, client, name, namespace, commit, initialRevision, clusterIDs)
+
+	logrus.Infof("Committing revision %s to the fixture repo...", updatedRevision)
+	commit = server.commitRevision(t, name, updatedRevision)
+
+	verifyRollout(t, client, name, namespace, commit, updatedRevision, clusterIDs)
+}
+
+// createClusterGroup creates a Fleet cluster group that selects the given clusters by their management cluster name.
+func createClusterGroup(client *rancher.Client, name string, clusterIDs []string) (*steveV1.SteveAPIObject, error) {
+	clusterGroup := map[string]any{
+		"type": clusterGroupSteveType,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": fleetDefaultNamespace,
+		},
+		"spec": map[string]any{
+			"selector": map[string]any{
+				"matchExpressions": []map[string]any{
+					{
+						"key":      clusterNameLabel,
+						"operator": "In",
+						"values":   clusterIDs,
+					},
+				},
+			},
+		},
+	}
+
+	logrus.Infof("Creating cluster group %s...", name)
+
+	return client.Steve.SteveType(clusterGroupSteveType).Create(clusterGroup)
+}
+
+// createGitRepo creates a Fleet GitRepo of the fixture repo targeting the cluster group of the same name.
+func createGitRepo(client *rancher.Client, name, repoURL string) (*steveV1.SteveAPIObject, error) {
+	gitRepo := map[string]any{
+		"type": gitRepoSteveType,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": fleetDefaultNamespace,
+		},
+		"spec": map[string]any{
+			"repo":            repoURL,
+			"branch":          fixtureBranch,
+			"pollingInterval": pollingInterval,
+			"targets": []map[string]any{
+				{"clusterGroup": name},
+			},
+		},
+	}
+
+	logrus.Infof("Creating GitRepo %s...", name)
+
+	return client.Steve.SteveType(gitRepoSteveType).Create(gitRepo)
+}
+
+// verifyRollout waits for the GitRepo to deploy the given commit with every bundle ready, and verifies the resources of the revision
+// are running on every cluster.
+func verifyRollout(t *testing.T, client *rancher.Client, name, namespace, commit, revision string, clusterIDs []string) {
+	logrus.Infof("Waiting for GitRepo %s to deploy commit %s...", name, commit)
+	err := kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		gitRepo, err := client.Steve.SteveType(gitRepoSteveType).ByID(fleetDefaultNamespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		status := &fleetStatus{}
+		if err := steveV1.ConvertToK8sType(gitRepo.Status, status); err != nil {
+			return false, err
+		}
+
+		if status.Commit != commit || status.Summary.DesiredReady != len(clusterIDs) || status.Summary.Ready != status.Summary.DesiredReady {
+			return false, nil
+		}
+
+		return bundlesReady(client, name)
+	})
+	require.NoError(t, err)
+
+	for _, clusterID := range clusterIDs {
+		steveclient, err := client.Steve.ProxyDownstream(clusterID)
+		require.NoError(t, err)
+
+		logrus.Infof("Verifying revision %s is running on cluster %s...", revision, clusterID)
+		err = kwait.PollUntilContextTimeout(context.TODO(), 5*time.Second, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+			return revisionRunning(steveclient, namespace, name, revision)
+		})
+		require.NoError(t, err)
+	}
+}
+
+// bundlesReady returns whether every bundle of the GitRepo is ready on all of its targets.
+func bundlesReady(client *rancher.Client, name string) (bool, error) {
+	bundles, err := client.Steve.SteveType(bundleSteveType).NamespacedSteveClient(fleetDefaultNamespace).List(map[string][]string{
+		"labelSelector": {repoNameLabel + "=" + name},
+	})
+	if err != nil || len(bundles.Data) == 0 {
+		return false, nil
+	}
+
+	for _, bundle := range bundles.Data {
+		status := &fleetStatus{}
+		if err := steveV1.ConvertToK8sType(bundle.Status, status); err != nil {
+			return false, err
+		}
+
+		if status.Summary.DesiredReady == 0 || status.Summary.Ready != status.Summary.DesiredReady {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// revisionRunning returns whether the config map holds the given revision and the deployment rolled out the pods of that revision.
+func revisionRunning(steveclient *steveV1.Client, namespace, name, revision string) (bool, error) {
+	configMapResp, err := steveclient.SteveType(stevetypes.ConfigMap).ByID(namespace + "/" + name)
+	if err != nil {
+		return false, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := steveV1.ConvertToK8sType(configMapResp.JSONResp, configMap); err != nil {
+		return false, err
+	}
+
+	if configMap.Data[revisionDataName] != revision {
+		return false, nil
+	}
+
+	deploymentResp, err := steveclient.SteveType(stevetypes.Deployment).ByID(namespace + "/" + name)
+	if err != nil {
+		return false, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment); err != nil {
+		return false, err
+	}
+
+	if deployment.Spec.Template.Annotations[revisionDataName] != revision {
+		return false, nil
+	}
+
+	return deployment.Status.ObservedGeneration == deployment.Generation && deployment.Status.UpdatedReplicas == *deployment.Spec.Replicas &&
+		deployment.Status.AvailableReplicas == *deployment.Spec.Replicas, nil
+}
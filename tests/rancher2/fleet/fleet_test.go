@@ -0,0 +1,105 @@
+package fleet
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type FleetTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (f *FleetTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	f.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(f.T(), err)
+
+	f.client = client
+
+	f.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	f.rancherConfig, f.terraformConfig, f.terratestConfig, _ = config.LoadTFPConfigs(f.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, f.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(f.T(), f.terraformConfig, f.terratestConfig, keyPath)
+	f.terraformOptions = terraformOptions
+}
+
+func (f *FleetTestSuite) TestTfpFleetGitRepo() {
+	var err error
+	var testUser, testPassword string
+
+	f.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(f.client)
+	require.NoError(f.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		nodeRoles []config.Nodepool
+	}{
+		{"Fleet_GitRepo", nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(f.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{f.cattleConfig})
+		require.NoError(f.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(f.T(), err)
+
+		provisioning.GetK8sVersion(f.T(), f.client, f.terratestConfig, f.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		f.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, f.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(f.T(), f.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(f.T(), f.client)
+			require.NoError(f.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(f.T(), f.client, f.standardUserClient, rancher, terraform, terratest, testUser, testPassword, f.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(f.T(), adminClient, clusterIDs)
+
+			VerifyFleetGitRepo(f.T(), adminClient, terratest, clusterIDs)
+		})
+	}
+
+	if f.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(f.terratestConfig)
+	}
+}
+
+func TestTfpFleetTestSuite(t *testing.T) {
+	suite.Run(t, new(FleetTestSuite))
+}
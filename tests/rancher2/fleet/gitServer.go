@@ -0,0 +1,171 @@
+package fleet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	defaultGitServerPort = 8989
+	fixtureRepo          = "fixture"
+	fixtureBranch        = "main"
+	fixtureManifest      = "manifests.yaml"
+	fleetFile            = "fleet.yaml"
+
+	fleetFileContent = "defaultNamespace: %s\n"
+	manifestContent  = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %[1]s
+data:
+  revision: "%[2]s"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %[1]s
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: %[1]s
+  template:
+    metadata:
+      labels:
+        app: %[1]s
+      annotations:
+        revision: "%[2]s"
+    spec:
+      containers:
+        - name: nginx
+          image: nginx
+          ports:
+            - containerPort: 80
+`
+)
+
+// gitServer serves the fixture repo through git http-backend, so Fleet can clone it over the smart HTTP protocol.
+type gitServer struct {
+	server  *http.Server
+	repoDir string
+	repoURL string
+}
+
+// startGitServer creates the fixture repo and serves it from the test host. The address defaults to the
+// address of the test host that reaches Rancher and can be overridden with fleetInput.gitServerAddress.
+func startGitServer(t *testing.T, rancherHost string, terratestConfig *config.TerratestConfig, namespace string) *gitServer {
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+
+	projectRoot := t.TempDir()
+	repoDir := filepath.Join(projectRoot, fixtureRepo)
+
+	require.NoError(t, os.MkdirAll(repoDir, 0755))
+
+	runGit(t, repoDir, "init")
+	runGit(t, repoDir, "symbolic-ref", "HEAD", "refs/heads/"+fixtureBranch)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, fleetFile), []byte(fmt.Sprintf(fleetFileContent, namespace)), 0644))
+
+	address, port := "", defaultGitServerPort
+	if terratestConfig.FleetInput != nil {
+		address = terratestConfig.FleetInput.GitServerAddress
+
+		if terratestConfig.FleetInput.GitServerPort != 0 {
+			port = terratestConfig.FleetInput.GitServerPort
+		}
+	}
+
+	if address == "" {
+		address, err = outboundAddress(rancherHost)
+		require.NoError(t, err)
+	}
+
+	handler := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env: []string{
+			"GIT_PROJECT_ROOT=" + projectRoot,
+			"GIT_HTTP_EXPORT_ALL=1",
+		},
+	}
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("Git server stopped: %v", err)
+		}
+	}()
+
+	gitServer := &gitServer{
+		server:  server,
+		repoDir: repoDir,
+		repoURL: "http://" + net.JoinHostPort(address, strconv.Itoa(port)) + "/" + fixtureRepo + "/.git",
+	}
+
+	logrus.Infof("Serving the fixture repo at %s", gitServer.repoURL)
+
+	return gitServer
+}
+
+// commitRevision writes the fixture manifests of the given revision, commits them and returns the commit hash.
+func (g *gitServer) commitRevision(t *testing.T, workloadName, revision string) string {
+	manifest := fmt.Sprintf(manifestContent, workloadName, revision)
+	require.NoError(t, os.WriteFile(filepath.Join(g.repoDir, fixtureManifest), []byte(manifest), 0644))
+
+	runGit(t, g.repoDir, "add", "-A")
+	runGit(t, g.repoDir, "-c", "user.name=tfp-automation", "-c", "user.email=tfp-automation@rancher.com", "commit", "-m", "Revision "+revision)
+
+	return runGit(t, g.repoDir, "rev-parse", "HEAD")
+}
+
+// stop shuts down the git server.
+func (g *gitServer) stop() {
+	_ = g.server.Close()
+}
+
+// runGit runs the git command in the given directory and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	return strings.TrimSpace(string(output))
+}
+
+// outboundAddress returns the local address used by the test host to reach the Rancher server.
+func outboundAddress(rancherHost string) (string, error) {
+	host := rancherHost
+	if parsedURL, err := url.Parse(rancherHost); err == nil && parsedURL.Host != "" {
+		host = parsedURL.Host
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+
+	conn, err := net.Dial("udp", host)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+}
@@ -0,0 +1,371 @@
+package hardened
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/clients/rancher/catalog"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	extensionscharts "github.com/rancher/shepherd/extensions/charts"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tests/actions/charts"
+	"github.com/rancher/tests/actions/projects"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	clusterScanSteveType          = "cis.cattle.io.clusterscan"
+	clusterScanProfileSteveType   = "cis.cattle.io.clusterscanprofile"
+	clusterScanBenchmarkSteveType = "cis.cattle.io.clusterscanbenchmark"
+	clusterScanReportSteveType    = "cis.cattle.io.clusterscanreport"
+
+	defaultRegistrySettingID = "system-default-registry"
+	serverURLSettingID       = "server-url"
+	systemProject            = "System"
+
+	pass        = "pass"
+	fail        = "fail"
+	permissive  = "permissive"
+	scan        = "scan"
+	rke1Profile = "rke-profile-hardened"
+	rke2Profile = "rke2-cis"
+	k3sProfile  = "k3s-cis"
+)
+
+var benchmarkVersionRegex = regexp.MustCompile(`[0-9]+(\.[0-9]+)+`)
+
+type scanSummary struct {
+	Total         int `json:"total"`
+	Pass          int `json:"pass"`
+	Fail          int `json:"fail"`
+	Skip          int `json:"skip"`
+	Warn          int `json:"warn"`
+	NotApplicable int `json:"notApplicable"`
+}
+
+type scanReport struct {
+	Results []struct {
+		Checks []struct {
+			ID          string `json:"id"`
+			Description string `json:"description"`
+			State       string `json:"state"`
+		} `json:"checks"`
+	} `json:"results"`
+}
+
+// VerifyCISBenchmark installs the rancher-cis-benchmark chart on the hardened cluster, runs a scan with the hardened profile of
+// the cluster type and fails when any check that was not skipped has failed.
+func VerifyCISBenchmark(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterName := terraformConfig.ResourcePrefix
+
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	err = installCISBenchmarkChart(client, clusterID, clusterName)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	scanProfileName, err := hardenedScanProfile(client, steveclient, clusterID, terraformConfig.Module)
+	require.NoError(t, err)
+
+	summary, scanName, err := runCISScan(steveclient, scanProfileName)
+	require.NoError(t, err)
+
+	logrus.Infof("CIS scan %s with profile %s: %d passed, %d failed, %d skipped, %d warned, %d not applicable", scanName, scanProfileName,
+		summary.Pass, summary.Fail, summary.Skip, summary.Warn, summary.NotApplicable)
+
+	if summary.Fail > 0 {
+		for _, check := range failedChecks(steveclient, scanName) {
+			logrus.Errorf("CIS check failed: %s", check)
+		}
+	}
+
+	require.Zero(t, summary.Fail, "CIS scan %s has failed checks", scanName)
+}
+
+// installCISBenchmarkChart installs the latest rancher-cis-benchmark chart in the System project of the cluster and waits for its
+// resources to be ready.
+func installCISBenchmarkChart(client *rancher.Client, clusterID, clusterName string) error {
+	clusterMeta, err := clusters.NewClusterMeta(client, clusterName)
+	if err != nil {
+		return err
+	}
+
+	project, err := projects.GetProjectByName(client, clusterID, systemProject)
+	if err != nil {
+		return err
+	}
+
+	version, err := client.Catalog.GetLatestChartVersion(charts.CISBenchmarkName, catalog.RancherChartRepo)
+	if err != nil {
+		return err
+	}
+
+	serverSetting, err := client.Management.Setting.ByID(serverURLSettingID)
+	if err != nil {
+		return err
+	}
+
+	registrySetting, err := client.Management.Setting.ByID(defaultRegistrySettingID)
+	if err != nil {
+		return err
+	}
+
+	payloadOpts := &charts.PayloadOpts{
+		InstallOptions: charts.InstallOptions{
+			Cluster:   clusterMeta,
+			Version:   version,
+			ProjectID: project.ID,
+		},
+		Name:            charts.CISBenchmarkName,
+		Namespace:       charts.CISBenchmarkNamespace,
+		Host:            serverSetting.Value,
+		DefaultRegistry: registrySetting.Value,
+	}
+
+	logrus.Infof("Installing %s chart %s...", charts.CISBenchmarkName, version)
+	err = charts.InstallHardenedChart(client, payloadOpts)
+	if err != nil {
+		return err
+	}
+
+	err = extensionscharts.WatchAndWaitDeployments(client, clusterID, charts.CISBenchmarkNamespace, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	return extensionscharts.WatchAndWaitDaemonSets(client, clusterID, charts.CISBenchmarkNamespace, metav1.ListOptions{})
+}
+
+// hardenedScanProfile returns the hardened scan profile shipped by the chart for the cluster type of the module, whose benchmark
+// supports the Kubernetes version of the cluster. When several benchmarks support it, the profile of the newest one is returned.
+func hardenedScanProfile(client *rancher.Client, steveclient *steveV1.Client, clusterID, module string) (string, error) {
+	prefix := rke2Profile
+
+	switch {
+	case strings.Contains(module, clustertypes.RKE1):
+		prefix = rke1Profile
+	case strings.Contains(module, clustertypes.K3S):
+		prefix = k3sProfile
+	}
+
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	if cluster.Version == nil {
+		return "", fmt.Errorf("Kubernetes version of cluster %v is not reported yet", clusterID)
+	}
+
+	kubernetesVersion, err := version.ParseGeneric(cluster.Version.GitVersion)
+	if err != nil {
+		return "", err
+	}
+
+	profiles, err := steveclient.SteveType(clusterScanProfileSteveType).List(nil)
+	if err != nil {
+		return "", err
+	}
+
+	var profileName string
+	var profileBenchmarkVersion *version.Version
+
+	for _, profile := range profiles.Data {
+		if !strings.HasPrefix(profile.Name, prefix) || strings.Contains(profile.Name, permissive) {
+			continue
+		}
+
+		profileSpec := struct {
+			BenchmarkVersion string `json:"benchmarkVersion"`
+		}{}
+
+		err = steveV1.ConvertToK8sType(profile.Spec, &profileSpec)
+		if err != nil {
+			return "", err
+		}
+
+		supported, err := benchmarkSupportsVersion(steveclient, profileSpec.BenchmarkVersion, kubernetesVersion)
+		if err != nil {
+			return "", err
+		}
+
+		if !supported {
+			logrus.Infof("Skipping CIS scan profile %s, benchmark %s does not support Kubernetes %s", profile.Name,
+				profileSpec.BenchmarkVersion, kubernetesVersion)
+			continue
+		}
+
+		benchmarkVersion, err := version.ParseGeneric(benchmarkVersionRegex.FindString(profileSpec.BenchmarkVersion))
+		if err != nil {
+			return "", fmt.Errorf("Invalid CIS benchmark version %v: %v", profileSpec.BenchmarkVersion, err)
+		}
+
+		if profileBenchmarkVersion == nil || profileBenchmarkVersion.LessThan(benchmarkVersion) {
+			profileName, profileBenchmarkVersion = profile.Name, benchmarkVersion
+		}
+	}
+
+	if profileName == "" {
+		return "", fmt.Errorf("No hardened CIS scan profile supports Kubernetes %v for module: %v", kubernetesVersion, module)
+	}
+
+	return profileName, nil
+}
+
+// benchmarkSupportsVersion returns true if the Kubernetes version is within the range supported by the given CIS benchmark.
+func benchmarkSupportsVersion(steveclient *steveV1.Client, benchmarkName string, kubernetesVersion *version.Version) (bool, error) {
+	benchmark, err := steveclient.SteveType(clusterScanBenchmarkSteveType).ByID(benchmarkName)
+	if err != nil {
+		return false, err
+	}
+
+	benchmarkSpec := struct {
+		MinKubernetesVersion string `json:"minKubernetesVersion"`
+		MaxKubernetesVersion string `json:"maxKubernetesVersion"`
+	}{}
+
+	err = steveV1.ConvertToK8sType(benchmark.Spec, &benchmarkSpec)
+	if err != nil {
+		return false, err
+	}
+
+	if benchmarkSpec.MinKubernetesVersion != "" {
+		minVersion, err := version.ParseGeneric(benchmarkSpec.MinKubernetesVersion)
+		if err != nil {
+			return false, err
+		}
+
+		if kubernetesVersion.LessThan(minVersion) {
+			return false, nil
+		}
+	}
+
+	if benchmarkSpec.MaxKubernetesVersion != "" {
+		maxVersion, err := version.ParseGeneric(benchmarkSpec.MaxKubernetesVersion)
+		if err != nil {
+			return false, err
+		}
+
+		// The max version is usually a minor version, e.g. 1.31.x, so only the major and minor versions are compared.
+		if maxVersion.Major() < kubernetesVersion.Major() ||
+			(maxVersion.Major() == kubernetesVersion.Major() && maxVersion.Minor() < kubernetesVersion.Minor()) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// runCISScan creates a cluster scan with the given profile and returns its summary once the scan has completed.
+func runCISScan(steveclient *steveV1.Client, scanProfileName string) (*scanSummary, string, error) {
+	clusterScan := map[string]any{
+		"type": clusterScanSteveType,
+		"metadata": map[string]any{
+			"name": namegen.AppendRandomString(scan),
+		},
+		"spec": map[string]any{
+			"scanProfileName": scanProfileName,
+			"scoreWarning":    pass,
+		},
+	}
+
+	logrus.Infof("Running CIS scan with profile %s...", scanProfileName)
+	scanResp, err := steveclient.SteveType(clusterScanSteveType).Create(clusterScan)
+	if err != nil {
+		return nil, "", err
+	}
+
+	summary := &scanSummary{}
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		scanResp, err = steveclient.SteveType(clusterScanSteveType).ByID(scanResp.ID)
+		if err != nil {
+			return false, nil
+		}
+
+		if scanResp.State != nil && scanResp.State.Error {
+			return false, fmt.Errorf("CIS scan %s failed: %s", scanResp.Name, scanResp.State.Message)
+		}
+
+		status, ok := scanResp.Status.(map[string]any)
+		if !ok || status["summary"] == nil || status["lastRunTimestamp"] == nil {
+			return false, nil
+		}
+
+		summaryBytes, err := json.Marshal(status["summary"])
+		if err != nil {
+			return false, err
+		}
+
+		return true, json.Unmarshal(summaryBytes, summary)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return summary, scanResp.Name, nil
+}
+
+// failedChecks returns the failed checks of the report generated by the given scan.
+func failedChecks(steveclient *steveV1.Client, scanName string) []string {
+	reports, err := steveclient.SteveType(clusterScanReportSteveType).List(nil)
+	if err != nil {
+		return nil
+	}
+
+	var checks []string
+	for _, report := range reports.Data {
+		if !ownedBy(report.OwnerReferences, scanName) {
+			continue
+		}
+
+		spec, ok := report.Spec.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		reportJSON, _ := spec["reportJSON"].(string)
+
+		parsedReport := &scanReport{}
+		if err := json.Unmarshal([]byte(reportJSON), parsedReport); err != nil {
+			continue
+		}
+
+		for _, result := range parsedReport.Results {
+			for _, check := range result.Checks {
+				if check.State == fail {
+					checks = append(checks, check.ID+" "+check.Description)
+				}
+			}
+		}
+	}
+
+	return checks
+}
+
+func ownedBy(ownerReferences []metav1.OwnerReference, name string) bool {
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
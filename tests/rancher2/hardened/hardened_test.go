@@ -0,0 +1,114 @@
+package hardened
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HardenedTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (h *HardenedTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	h.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(h.T(), err)
+
+	h.client = client
+
+	h.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	h.rancherConfig, h.terraformConfig, h.terratestConfig, _ = config.LoadTFPConfigs(h.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, h.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(h.T(), h.terraformConfig, h.terratestConfig, keyPath)
+	h.terraformOptions = terraformOptions
+}
+
+func (h *HardenedTestSuite) TestTfpHardened() {
+	var err error
+	var testUser, testPassword string
+
+	h.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(h.client)
+	require.NoError(h.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		nodeRoles []config.Nodepool
+	}{
+		{"Hardened_CIS_Scan", nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(h.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{h.cattleConfig})
+		require.NoError(h.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(h.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "hardened"}, true, configMap[0])
+		require.NoError(h.T(), err)
+
+		provisioning.GetK8sVersion(h.T(), h.client, h.terratestConfig, h.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		if strings.Contains(terraform.Module, defaults.Custom) || strings.Contains(terraform.Module, defaults.Airgap) || strings.Contains(terraform.Module, defaults.Import) {
+			h.T().Skip("The hardened node user-data is only rendered for node driver clusters")
+		}
+
+		h.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, h.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(h.T(), h.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(h.T(), h.client)
+			require.NoError(h.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(h.T(), h.client, h.standardUserClient, rancher, terraform, terratest, testUser, testPassword, h.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(h.T(), adminClient, clusterIDs)
+
+			VerifyCISBenchmark(h.T(), adminClient, terraform)
+		})
+	}
+
+	if h.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(h.terratestConfig)
+	}
+}
+
+func TestTfpHardenedTestSuite(t *testing.T) {
+	suite.Run(t, new(HardenedTestSuite))
+}
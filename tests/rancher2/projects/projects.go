@@ -0,0 +1,360 @@
+package projects
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/workloads"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tests/actions/projects"
+	"github.com/rancher/tests/actions/workloads/deployment"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	nginxImage = "nginx"
+
+	withinQuotaPrefix  = "within-quota"
+	exceedQuotaPrefix  = "exceed-quota"
+	exhaustQuotaPrefix = "exhaust-quota"
+	exceededQuotaError = "exceeded quota"
+
+	projectIDAnnotation = "field.cattle.io/projectId"
+	maxQuotaNamespaces  = 20
+	minimalCPU          = "1m"
+	minimalMemory       = "1Mi"
+)
+
+// VerifyProjects verifies every project of the cluster exists and that its namespaces enforce the configured quotas. In every
+// namespace a workload without resources is deployed within the quota and gets the default container limits, while a pod
+// requesting more than the quota is rejected at admission. Projects with a resource quota are then filled with namespaces until
+// the quota of the project is used up, and a workload in the next namespace is rejected.
+func VerifyProjects(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	for _, projectConfig := range terraformConfig.Projects {
+		logrus.Infof("Verifying project %s...", projectConfig.Name)
+		project, err := projects.GetProjectByName(client, clusterID, projectConfig.Name)
+		require.NoError(t, err)
+
+		for _, namespaceConfig := range projectConfig.Namespaces {
+			namespaceQuota := namespaceConfig.ResourceQuota
+			if namespaceQuota == nil {
+				namespaceQuota = projectConfig.NamespaceDefaultResourceQuota
+			}
+
+			containerLimit := namespaceConfig.ContainerResourceLimit
+			if containerLimit == nil {
+				containerLimit = projectConfig.ContainerResourceLimit
+			}
+
+			if namespaceQuota != nil {
+				logrus.Infof("Verifying resource quota of namespace %s...", namespaceConfig.Name)
+				err = waitForResourceQuota(steveclient, namespaceConfig.Name, namespaceQuota)
+				require.NoError(t, err)
+			}
+
+			verifyWithinQuota(t, steveclient, namespaceConfig.Name, containerLimit)
+
+			if namespaceQuota != nil {
+				verifyExceedQuota(t, steveclient, namespaceConfig.Name, namespaceQuota)
+			}
+		}
+
+		if projectConfig.ResourceQuota != nil {
+			verifyProjectQuotaExhausted(t, steveclient, project, projectConfig)
+		}
+	}
+}
+
+// verifyWithinQuota deploys a workload without resources in the namespace and verifies it becomes active with the default container
+// limits of the namespace.
+func verifyWithinQuota(t *testing.T, steveclient *steveV1.Client, namespace string, containerLimit *management.ContainerResourceLimit) {
+	deploymentName := namegen.AppendRandomString(withinQuotaPrefix)
+	containerTemplate := workloads.NewContainer(nginxImage, nginxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+	deploymentTemplate := workloads.NewDeploymentTemplate(deploymentName, namespace, podTemplate, true, nil)
+
+	logrus.Infof("Deploying %s within the quota of namespace %s...", deploymentName, namespace)
+	deploymentResp, err := steveclient.SteveType(stevetypes.Deployment).Create(deploymentTemplate)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, steveclient.SteveType(stevetypes.Deployment).Delete(deploymentResp))
+	}()
+
+	err = deployment.VerifyDeployment(steveclient, deploymentResp)
+	require.NoError(t, err)
+
+	if containerLimit == nil {
+		return
+	}
+
+	query := url.Values{"labelSelector": {labels.SelectorFromSet(deploymentTemplate.Spec.Template.Labels).String()}}
+
+	pods, err := steveclient.SteveType(stevetypes.Pod).NamespacedSteveClient(namespace).List(query)
+	require.NoError(t, err)
+	require.NotEmpty(t, pods.Data)
+
+	pod := &corev1.Pod{}
+	err = steveV1.ConvertToK8sType(pods.Data[0].JSONResp, pod)
+	require.NoError(t, err)
+
+	resources := pod.Spec.Containers[0].Resources
+
+	logrus.Infof("Verifying the default container limits of pod %s...", pod.Name)
+	expectedResources := []struct {
+		resources corev1.ResourceList
+		name      corev1.ResourceName
+		value     string
+	}{
+		{resources.Limits, corev1.ResourceCPU, containerLimit.LimitsCPU},
+		{resources.Limits, corev1.ResourceMemory, containerLimit.LimitsMemory},
+		{resources.Requests, corev1.ResourceCPU, containerLimit.RequestsCPU},
+		{resources.Requests, corev1.ResourceMemory, containerLimit.RequestsMemory},
+	}
+
+	for _, expected := range expectedResources {
+		if expected.value == "" {
+			continue
+		}
+
+		actual, ok := expected.resources[expected.name]
+		require.True(t, ok, "Pod %s is missing the default %s", pod.Name, expected.name)
+		require.Zero(t, actual.Cmp(resource.MustParse(expected.value)), "Pod %s has %s %s, expected %s", pod.Name, expected.name,
+			actual.String(), expected.value)
+	}
+}
+
+// verifyExceedQuota creates a pod requesting more CPU or memory than the quota of the namespace allows and verifies it is rejected
+// at admission.
+func verifyExceedQuota(t *testing.T, steveclient *steveV1.Client, namespace string, namespaceQuota *management.ResourceQuotaLimit) {
+	exceedingResources := corev1.ResourceList{}
+
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    firstSet(namespaceQuota.LimitsCPU, namespaceQuota.RequestsCPU),
+		corev1.ResourceMemory: firstSet(namespaceQuota.LimitsMemory, namespaceQuota.RequestsMemory),
+	} {
+		if value == "" {
+			continue
+		}
+
+		quantity := resource.MustParse(value)
+		quantity.Add(quantity)
+		exceedingResources[name] = quantity
+	}
+
+	if len(exceedingResources) == 0 {
+		logrus.Warningf("Namespace %s has no CPU or memory quota, skipping the admission check", namespace)
+		return
+	}
+
+	logrus.Infof("Creating a pod exceeding the quota of namespace %s...", namespace)
+	err := createQuotaPod(steveclient, exceedQuotaPrefix, namespace, exceedingResources)
+	require.Error(t, err, "Pod exceeding the quota of namespace %s was admitted", namespace)
+	require.Contains(t, err.Error(), exceededQuotaError)
+}
+
+// verifyProjectQuotaExhausted creates namespaces in the project until its resource quota is used up, which Rancher reports by
+// giving the next namespace a quota of zero, and verifies a workload in that namespace is rejected at admission.
+func verifyProjectQuotaExhausted(t *testing.T, steveclient *steveV1.Client, project *management.Project, projectConfig config.Project) {
+	exhaustedNamespace := ""
+
+	for i := 0; i < maxQuotaNamespaces && exhaustedNamespace == ""; i++ {
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        namegen.AppendRandomString(exhaustQuotaPrefix),
+				Annotations: map[string]string{projectIDAnnotation: project.ID},
+			},
+		}
+
+		logrus.Infof("Creating namespace %s in project %s...", namespace.Name, projectConfig.Name)
+		namespaceResp, err := steveclient.SteveType(stevetypes.Namespace).Create(namespace)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, steveclient.SteveType(stevetypes.Namespace).Delete(namespaceResp))
+		}()
+
+		zeroed, err := waitForNamespaceQuota(steveclient, namespace.Name)
+		require.NoError(t, err)
+
+		if zeroed {
+			exhaustedNamespace = namespace.Name
+		}
+	}
+
+	require.NotEmpty(t, exhaustedNamespace, "Project %s has quota left after %d namespaces", projectConfig.Name, maxQuotaNamespaces)
+
+	namespaceQuota := projectConfig.NamespaceDefaultResourceQuota
+	if firstSet(namespaceQuota.Pods, namespaceQuota.LimitsCPU, namespaceQuota.RequestsCPU, namespaceQuota.LimitsMemory, namespaceQuota.RequestsMemory) == "" {
+		logrus.Warningf("Project %s has no pod, CPU or memory quota, skipping the admission check", projectConfig.Name)
+		return
+	}
+
+	minimalResources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(minimalCPU),
+		corev1.ResourceMemory: resource.MustParse(minimalMemory),
+	}
+
+	logrus.Infof("Creating a pod in namespace %s of the exhausted project %s...", exhaustedNamespace, projectConfig.Name)
+	err := createQuotaPod(steveclient, exhaustQuotaPrefix, exhaustedNamespace, minimalResources)
+	require.Error(t, err, "Pod in namespace %s of the exhausted project %s was admitted", exhaustedNamespace, projectConfig.Name)
+	require.Contains(t, err.Error(), exceededQuotaError)
+}
+
+// createQuotaPod creates a pod with the given resources in the namespace, deleting it again if it is admitted, and returns the
+// error of its creation.
+func createQuotaPod(steveclient *steveV1.Client, prefix, namespace string, resources corev1.ResourceList) error {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namegen.AppendRandomString(prefix),
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  nginxImage,
+					Image: nginxImage,
+					Resources: corev1.ResourceRequirements{
+						Limits:   resources,
+						Requests: resources,
+					},
+				},
+			},
+		},
+	}
+
+	podResp, err := steveclient.SteveType(stevetypes.Pod).Create(pod)
+	if err == nil {
+		_ = steveclient.SteveType(stevetypes.Pod).Delete(podResp)
+	}
+
+	return err
+}
+
+// waitForNamespaceQuota waits for Rancher to create the resource quota of the namespace and returns whether every hard limit of
+// it is zero, which is the case when the project has no quota left for the namespace.
+func waitForNamespaceQuota(steveclient *steveV1.Client, namespace string) (bool, error) {
+	zeroed := false
+
+	err := kwait.PollUntilContextTimeout(context.TODO(), defaults.FiveSecondTimeout, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		resourceQuotas, err := steveclient.SteveType(stevetypes.ResourceQuota).NamespacedSteveClient(namespace).List(nil)
+		if err != nil || len(resourceQuotas.Data) == 0 {
+			return false, nil
+		}
+
+		resourceQuota := &corev1.ResourceQuota{}
+		if err := steveV1.ConvertToK8sType(resourceQuotas.Data[0].JSONResp, resourceQuota); err != nil {
+			return false, err
+		}
+
+		if len(resourceQuota.Spec.Hard) == 0 {
+			return false, nil
+		}
+
+		zeroed = true
+		for _, quantity := range resourceQuota.Spec.Hard {
+			if !quantity.IsZero() {
+				zeroed = false
+			}
+		}
+
+		return true, nil
+	})
+
+	return zeroed, err
+}
+
+// waitForResourceQuota waits for Rancher to create the resource quota of the namespace with the hard limits of the config.
+func waitForResourceQuota(steveclient *steveV1.Client, namespace string, namespaceQuota *management.ResourceQuotaLimit) error {
+	expectedHard := quotaHardLimits(namespaceQuota)
+
+	return kwait.PollUntilContextTimeout(context.TODO(), defaults.FiveSecondTimeout, defaults.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		resourceQuotas, err := steveclient.SteveType(stevetypes.ResourceQuota).NamespacedSteveClient(namespace).List(nil)
+		if err != nil {
+			return false, nil
+		}
+
+		for _, resourceQuotaResp := range resourceQuotas.Data {
+			resourceQuota := &corev1.ResourceQuota{}
+			if err := steveV1.ConvertToK8sType(resourceQuotaResp.JSONResp, resourceQuota); err != nil {
+				return false, err
+			}
+
+			if hardLimitsMatch(resourceQuota.Spec.Hard, expectedHard) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
+
+// quotaHardLimits returns the hard limits of the Kubernetes resource quota matching the quota of the config.
+func quotaHardLimits(namespaceQuota *management.ResourceQuotaLimit) map[corev1.ResourceName]string {
+	hardLimits := map[corev1.ResourceName]string{
+		corev1.ResourceConfigMaps:             namespaceQuota.ConfigMaps,
+		corev1.ResourceLimitsCPU:              namespaceQuota.LimitsCPU,
+		corev1.ResourceLimitsMemory:           namespaceQuota.LimitsMemory,
+		corev1.ResourcePersistentVolumeClaims: namespaceQuota.PersistentVolumeClaims,
+		corev1.ResourcePods:                   namespaceQuota.Pods,
+		corev1.ResourceReplicationControllers: namespaceQuota.ReplicationControllers,
+		corev1.ResourceRequestsCPU:            namespaceQuota.RequestsCPU,
+		corev1.ResourceRequestsMemory:         namespaceQuota.RequestsMemory,
+		corev1.ResourceRequestsStorage:        namespaceQuota.RequestsStorage,
+		corev1.ResourceSecrets:                namespaceQuota.Secrets,
+		corev1.ResourceServices:               namespaceQuota.Services,
+		corev1.ResourceServicesLoadBalancers:  namespaceQuota.ServicesLoadBalancers,
+		corev1.ResourceServicesNodePorts:      namespaceQuota.ServicesNodePorts,
+	}
+
+	for name, value := range hardLimits {
+		if value == "" {
+			delete(hardLimits, name)
+		}
+	}
+
+	return hardLimits
+}
+
+// hardLimitsMatch returns whether the hard limits of the resource quota hold every expected limit.
+func hardLimitsMatch(hard corev1.ResourceList, expectedHard map[corev1.ResourceName]string) bool {
+	for name, value := range expectedHard {
+		actual, ok := hard[name]
+		if !ok || actual.Cmp(resource.MustParse(value)) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstSet returns the first of the values that is set.
+func firstSet(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
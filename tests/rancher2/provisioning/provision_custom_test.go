@@ -17,6 +17,7 @@ import (
 	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
@@ -67,13 +68,18 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 	require.NoError(p.T(), err)
 
 	tests := []struct {
-		name   string
-		module string
+		name     string
+		module   string
+		provider string
 	}{
-		{"Custom_TFP_RKE2", modules.CustomEC2RKE2},
-		{"Custom_TFP_RKE2_Windows_2019", modules.CustomEC2RKE2Windows2019},
-		{"Custom_TFP_RKE2_Windows_2022", modules.CustomEC2RKE2Windows2022},
-		{"Custom_TFP_K3S", modules.CustomEC2K3s},
+		{"Custom_TFP_RKE2", modules.CustomEC2RKE2, providers.AWS},
+		{"Custom_TFP_RKE2_Windows_2019", modules.CustomEC2RKE2Windows2019, providers.AWS},
+		{"Custom_TFP_RKE2_Windows_2022", modules.CustomEC2RKE2Windows2022, providers.AWS},
+		{"Custom_TFP_RKE2_Windows_2025", modules.CustomEC2RKE2Windows2025, providers.AWS},
+		{"Custom_TFP_K3S", modules.CustomEC2K3s, providers.AWS},
+		{"Custom_TFP_Vsphere_RKE2_Windows_2019", modules.CustomVsphereRKE2Windows2019, providers.Vsphere},
+		{"Custom_TFP_Vsphere_RKE2_Windows_2022", modules.CustomVsphereRKE2Windows2022, providers.Vsphere},
+		{"Custom_TFP_Vsphere_RKE2_Windows_2025", modules.CustomVsphereRKE2Windows2025, providers.Vsphere},
 	}
 
 	for _, tt := range tests {
@@ -91,6 +97,10 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
 
 		p.Run((tt.name), func() {
+			if terraform.Provider != tt.provider {
+				p.T().Skipf("Module %s requires provider %s", tt.module, tt.provider)
+			}
+
 			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
 			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
 
@@ -103,6 +113,7 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 			if strings.Contains(terraform.Module, clustertypes.WINDOWS) {
 				clusterIDs, _ = provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, true, true, true, customClusterNames)
 				provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+				provisioning.VerifyWindowsWorkloads(p.T(), adminClient, clusterIDs, terraform)
 			}
 		})
 
@@ -157,6 +168,7 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustomDynamicInput() {
 			if strings.Contains(p.terraformConfig.Module, clustertypes.WINDOWS) {
 				clusterIDs, _ = provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, true, true, true, customClusterNames)
 				provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+				provisioning.VerifyWindowsWorkloads(p.T(), adminClient, clusterIDs, terraform)
 			}
 		})
 
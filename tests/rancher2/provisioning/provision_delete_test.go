@@ -0,0 +1,127 @@
+package provisioning
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProvisionDeleteTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (p *ProvisionDeleteTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	p.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(p.T(), err)
+
+	p.client = client
+
+	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+	p.terraformOptions = terraformOptions
+}
+
+func (p *ProvisionDeleteTestSuite) TestTfpDeleteCluster() {
+	var err error
+	var testUser, testPassword string
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	tests := []struct {
+		name string
+	}{
+		{"Delete_Cluster"},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
+		require.NoError(p.T(), err)
+
+		provisioning.GetK8sVersion(p.T(), p.client, p.terratestConfig, p.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraformConfig, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		p.Run((tt.name), func() {
+			instanceFinder, err := provisioning.NewInstanceFinder(terraformConfig)
+			if err != nil {
+				p.T().Skipf("Orphaned instances cannot be detected: %v", err)
+			}
+
+			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+			require.NoError(p.T(), err)
+
+			containsCustomModule := strings.Contains(terraformConfig.Module, defaults.Custom)
+
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraformConfig, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, containsCustomModule, nil)
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+
+			instances, err := provisioning.FindRunningInstances(instanceFinder, terraformConfig.ResourcePrefix)
+			require.NoError(p.T(), err)
+			require.NotEmptyf(p.T(), instances, "No instances named after %s were found", terraformConfig.ResourcePrefix)
+
+			logrus.Infof("Destroying cluster %s...", terraformConfig.ResourcePrefix)
+			terraform.Destroy(p.T(), p.terraformOptions)
+
+			provisioning.VerifyClusterDeletion(p.T(), adminClient, terraformConfig.ResourcePrefix)
+			provisioning.VerifyNoOrphanedInstances(p.T(), instanceFinder, terraformConfig.ResourcePrefix)
+
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+			err = cleanup.TFFilesCleanup(keyPath)
+			if err != nil {
+				logrus.Warning(err)
+			}
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+func TestTfpProvisionDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvisionDeleteTestSuite))
+}
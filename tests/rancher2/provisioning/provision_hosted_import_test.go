@@ -0,0 +1,136 @@
+package provisioning
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProvisionHostedImportTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (p *ProvisionHostedImportTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	p.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(p.T(), err)
+
+	p.client = client
+
+	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+	p.terraformOptions = terraformOptions
+}
+
+func (p *ProvisionHostedImportTestSuite) TestTfpProvisionHostedImport() {
+	var err error
+	var testUser, testPassword string
+
+	if p.terraformConfig.HostedImport == nil {
+		p.T().Skip("Hosted import is not set")
+	}
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	tests := []struct {
+		name        string
+		module      string
+		clusterName string
+	}{
+		{"Import_AKS_Cluster", modules.ImportAKS, p.terraformConfig.HostedImport.AKSClusterName},
+		{"Import_EKS_Cluster", modules.ImportEKS, p.terraformConfig.HostedImport.EKSClusterName},
+		{"Import_GKE_Cluster", modules.ImportGKE, p.terraformConfig.HostedImport.GKEClusterName},
+	}
+
+	for _, tt := range tests {
+		if tt.clusterName == "" {
+			logrus.Infof("Skipping %s, no existing cluster is set", tt.name)
+			continue
+		}
+
+		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
+		require.NoError(p.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(p.T(), err)
+
+		rancher, terraformConfig, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		p.Run((tt.name), func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+			require.NoError(p.T(), err)
+
+			hostedCluster, err := provisioning.FindHostedCluster(terraformConfig, tt.module)
+			require.NoError(p.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraformConfig, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+
+			discoveredPools := provisioning.VerifyHostedImport(p.T(), adminClient, clusterIDs[0], tt.module)
+			provisioning.VerifyHostedNodePoolsUnchanged(p.T(), terraformConfig, tt.module, hostedCluster)
+
+			logrus.Infof("Removing cluster %s from Rancher...", terraformConfig.ResourcePrefix)
+			terraform.Destroy(p.T(), p.terraformOptions)
+
+			removedClusterID, err := clusterExtensions.GetClusterIDByName(adminClient, terraformConfig.ResourcePrefix)
+			require.NoError(p.T(), err)
+			require.Empty(p.T(), removedClusterID)
+
+			provisioning.VerifyHostedClusterIntact(p.T(), terraformConfig, tt.module, discoveredPools)
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+func TestTfpProvisionHostedImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvisionHostedImportTestSuite))
+}
@@ -103,6 +103,84 @@ func (p *ProvisionHostedTestSuite) TestTfpProvisionHosted() {
 
 			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+			provisioning.VerifyHostedNodePools(p.T(), adminClient, clusterIDs[0], terraform, terratest)
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+func (p *ProvisionHostedTestSuite) TestTfpProvisionHostedMultiplePools() {
+	var err error
+	var testUser, testPassword string
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	poolLabels := map[string]string{"tfp-automation": "hosted"}
+	poolTaints := []string{"tfp-automation=hosted:PreferNoSchedule"}
+
+	aksNodePools := []config.Nodepool{
+		{Quantity: 2},
+		{Quantity: 1, MinSize: 1, MaxSize: 3, Labels: poolLabels, Taints: poolTaints},
+	}
+	eksNodePools := []config.Nodepool{
+		{DiskSize: 100, InstanceType: p.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2},
+		{DiskSize: 100, InstanceType: p.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 1, MaxSize: 3, MinSize: 1, Labels: poolLabels, Taints: poolTaints,
+			Spot: true},
+	}
+	gkeNodePools := []config.Nodepool{
+		{Quantity: 2, MaxPodsConstraint: 110},
+		{Quantity: 1, MaxPodsConstraint: 110, MinSize: 1, MaxSize: 3, Labels: poolLabels, Taints: poolTaints, Spot: true},
+	}
+
+	tests := []struct {
+		name              string
+		module            string
+		nodePools         []config.Nodepool
+		kubernetesVersion string
+	}{
+		{"Provision_AKS_Cluster_Multiple_Pools", modules.AKS, aksNodePools, p.terratestConfig.AKSKubernetesVersion},
+		{"Provision_EKS_Cluster_Multiple_Pools", modules.EKS, eksNodePools, p.terratestConfig.EKSKubernetesVersion},
+		{"Provision_GKE_Cluster_Multiple_Pools", modules.GKE, gkeNodePools, p.terratestConfig.GKEKubernetesVersion},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
+		require.NoError(p.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(p.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodePools, configMap[0])
+		require.NoError(p.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, tt.kubernetesVersion, configMap[0])
+		require.NoError(p.T(), err)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		p.Run((tt.name), func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+			require.NoError(p.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+			provisioning.VerifyHostedNodePools(p.T(), adminClient, clusterIDs[0], terraform, terratest)
 		})
 
 		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
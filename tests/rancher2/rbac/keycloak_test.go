@@ -0,0 +1,122 @@
+package rbac
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/authproviders"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/keycloak"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/rancher/tfp-automation/tests/extensions/rbac"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeycloakTestSuite struct {
+	suite.Suite
+	client                   *rancher.Client
+	session                  *session.Session
+	cattleConfig             map[string]any
+	rancherConfig            *rancher.Config
+	terraformConfig          *config.TerraformConfig
+	terratestConfig          *config.TerratestConfig
+	terraformOptions         *terraform.Options
+	keycloakTerraformOptions *terraform.Options
+	keycloak                 *keycloak.Server
+}
+
+func (k *KeycloakTestSuite) TearDownSuite() {
+	_, keyPath := rancher2.SetKeyPath(keypath.KeycloakKeyPath, k.terratestConfig.PathToRepo, k.terraformConfig.Provider)
+	cleanup.Cleanup(k.T(), k.keycloakTerraformOptions, keyPath)
+}
+
+func (k *KeycloakTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	k.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(k.T(), err)
+
+	k.client = client
+
+	k.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	k.rancherConfig, k.terraformConfig, k.terratestConfig, _ = config.LoadTFPConfigs(k.cattleConfig)
+
+	_, keycloakKeyPath := rancher2.SetKeyPath(keypath.KeycloakKeyPath, k.terratestConfig.PathToRepo, k.terraformConfig.Provider)
+	k.keycloakTerraformOptions = framework.Setup(k.T(), k.terraformConfig, k.terratestConfig, keycloakKeyPath)
+
+	k.keycloak, err = keycloak.CreateMainTF(k.T(), k.keycloakTerraformOptions, keycloakKeyPath, k.rancherConfig, k.terraformConfig, k.terratestConfig)
+	require.NoError(k.T(), err)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, k.terratestConfig.PathToRepo, "")
+	k.terraformOptions = framework.Setup(k.T(), k.terraformConfig, k.terratestConfig, keyPath)
+}
+
+func (k *KeycloakTestSuite) TestTfpKeycloak() {
+	tests := []struct {
+		name         string
+		authProvider string
+		configKey    string
+		authConfig   any
+	}{
+		{"Keycloak_SAML", authproviders.Keycloak, "keycloakConfig", k.keycloak.SAMLConfig(k.rancherConfig.Host)},
+		{"Keycloak_OIDC", authproviders.KeycloakOIDC, "keycloakOIDCConfig", k.keycloak.OIDCConfig()},
+		{"Generic_OIDC", authproviders.GenericOIDC, "genericOIDCConfig", k.keycloak.OIDCConfig()},
+	}
+
+	testUser, testPassword := configs.CreateTestCredentials()
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(k.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{k.cattleConfig})
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "authProvider"}, tt.authProvider, configMap[0])
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", tt.configKey}, tt.authConfig, configMap[0])
+		require.NoError(k.T(), err)
+
+		rancher, terraform, _, _ := config.LoadTFPConfigs(configMap[0])
+
+		k.Run((tt.name), func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, k.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(k.T(), k.terraformOptions, keyPath)
+
+			rbac.AuthConfig(k.T(), rancher, terraform, k.terraformOptions, testUser, testPassword, configMap, newFile, rootBody, file)
+
+			rbac.VerifyKeycloakLogin(k.T(), k.client, k.keycloak, tt.authProvider)
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if k.terratestConfig.LocalQaseReporting {
+		results.ReportTest(k.terratestConfig)
+	}
+}
+
+func TestTfpKeycloakTestSuite(t *testing.T) {
+	suite.Run(t, new(KeycloakTestSuite))
+}
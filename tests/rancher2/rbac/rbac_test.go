@@ -26,6 +26,33 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+var defaultRoleTemplates = []map[string]any{
+	{
+		"name":    "tfp-cluster-secrets-viewer",
+		"context": "cluster",
+		"rules": []map[string]any{
+			{"apiGroups": []string{""}, "resources": []string{"secrets"}, "verbs": []string{"get", "list", "watch"}},
+		},
+	},
+	{
+		"name":            "tfp-project-deployer",
+		"context":         "project",
+		"roleTemplateIds": []string{"read-only"},
+		"rules": []map[string]any{
+			{"apiGroups": []string{"apps"}, "resources": []string{"deployments"}, "verbs": []string{"create", "update", "patch", "delete"}},
+		},
+	},
+}
+
+var defaultGlobalRoles = []map[string]any{
+	{
+		"name": "tfp-catalog-viewer",
+		"rules": []map[string]any{
+			{"apiGroups": []string{"catalog.cattle.io"}, "resources": []string{"clusterrepos"}, "verbs": []string{"get", "list", "watch"}},
+		},
+	},
+}
+
 type RBACTestSuite struct {
 	suite.Suite
 	client             *rancher.Client
@@ -70,6 +97,7 @@ func (r *RBACTestSuite) TestTfpRBAC() {
 	}{
 		{"Cluster_Owner", config.ClusterOwner},
 		{"Project_Owner", config.ProjectOwner},
+		{"Custom_Roles", config.CustomRoles},
 	}
 
 	for _, tt := range tests {
@@ -82,6 +110,14 @@ func (r *RBACTestSuite) TestTfpRBAC() {
 		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, nodeRolesDedicated, configMap[0])
 		require.NoError(r.T(), err)
 
+		if tt.rbacRole == config.CustomRoles && len(r.terraformConfig.RoleTemplates) == 0 && len(r.terraformConfig.GlobalRoles) == 0 {
+			_, err = operations.ReplaceValue([]string{"terraform", "roleTemplates"}, defaultRoleTemplates, configMap[0])
+			require.NoError(r.T(), err)
+
+			_, err = operations.ReplaceValue([]string{"terraform", "globalRoles"}, defaultGlobalRoles, configMap[0])
+			require.NoError(r.T(), err)
+		}
+
 		provisioning.GetK8sVersion(r.T(), r.client, r.terratestConfig, r.terraformConfig, configs.DefaultK8sVersion, configMap)
 
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
@@ -96,6 +132,12 @@ func (r *RBACTestSuite) TestTfpRBAC() {
 			clusterIDs, _ := provisioning.Provision(r.T(), r.client, r.standardUserClient, rancher, terraform, terratest, testUser, testPassword, r.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(r.T(), adminClient, clusterIDs)
 			rb.RBAC(r.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, r.terraformOptions, configMap, tt.rbacRole, newFile, rootBody, file)
+
+			if tt.rbacRole == config.CustomRoles {
+				rb.VerifyCustomRoles(r.T(), adminClient, terraform)
+			}
+
+			rb.VerifyPermissions(r.T(), adminClient, terraform, tt.rbacRole)
 		})
 
 		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
@@ -0,0 +1,332 @@
+package secretsencryption
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/norman/types"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/sshkeys"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/shepherd/pkg/nodes"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	capiClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+	defaultNamespace     = "default"
+	machineEtcdRoleLabel = "rke.cattle.io/etcd-role"
+	namespace            = "fleet-default"
+	nodeConfigLink       = "nodeConfig"
+	secretDataKey        = "data"
+	secretName           = "tfp-encrypted-secret"
+	sshKeyFile           = "id_rsa"
+
+	k3sEncryptionEnabled = "Encryption Status: Enabled"
+	k3sSecretsEncrypt    = "sudo k3s secrets-encrypt status"
+
+	rke1Etcdctl = "sudo docker exec etcd etcdctl"
+	rke2Crictl  = "sudo /var/lib/rancher/rke2/bin/crictl --runtime-endpoint unix:///run/k3s/containerd/containerd.sock"
+	rke2EtcdTLS = "/var/lib/rancher/rke2/server/tls/etcd/"
+)
+
+// Secrets encrypted at rest are stored in etcd as k8s:enc:<provider>:v1:<key name>:<ciphertext>.
+var encryptedSecretRegex = regexp.MustCompile(`k8s:enc:[a-z]+:v1:([^:]+):`)
+
+// The active key is marked with a * in the key list of k3s secrets-encrypt status, e.g. " *      AESCBC    aescbckey-2024-09-05T14:10:51Z".
+var k3sActiveKeyRegex = regexp.MustCompile(`(?m)^\s*\*\s+\S+\s+(\S+)\s*$`)
+
+// RotateEncryptionKeys creates a secret, rotates the secrets encryption key of the cluster and verifies through etcdctl that the
+// secret is stored encrypted with the new key and remains readable.
+func RotateEncryptionKeys(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namegen.AppendRandomString(secretName),
+			Namespace: defaultNamespace,
+		},
+		Data: map[string][]byte{
+			secretDataKey: []byte(namegen.RandStringLower(16)),
+		},
+	}
+
+	logrus.Infof("Creating secret %s...", secret.Name)
+	secretResp, err := steveclient.SteveType(stevetypes.Secret).Create(secret)
+	require.NoError(t, err)
+
+	etcdNode, err := getEtcdSSHNode(client, terraformConfig, clusterID)
+	require.NoError(t, err)
+
+	initialKey, err := getEncryptionKey(etcdNode, terraformConfig, secret.Namespace, secret.Name)
+	require.NoError(t, err)
+
+	logrus.Infof("Secret %s is encrypted with key %s", secret.Name, initialKey)
+
+	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		rotateRKE1EncryptionKey(t, client, clusterID)
+	} else {
+		rotateV2EncryptionKeys(t, client, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, clusterID, terraformOptions,
+			configMap, newFile, rootBody, file)
+	}
+
+	rotatedKey, err := getEncryptionKey(etcdNode, terraformConfig, secret.Namespace, secret.Name)
+	require.NoError(t, err)
+
+	logrus.Infof("Secret %s is encrypted with key %s", secret.Name, rotatedKey)
+	require.NotEqual(t, initialKey, rotatedKey)
+
+	logrus.Infof("Verifying secret %s is still readable...", secret.Name)
+	rotatedSecretResp, err := steveclient.SteveType(stevetypes.Secret).ByID(secretResp.ID)
+	require.NoError(t, err)
+
+	rotatedSecret := &corev1.Secret{}
+	err = steveV1.ConvertToK8sType(rotatedSecretResp.JSONResp, rotatedSecret)
+	require.NoError(t, err)
+	require.Equal(t, secret.Data, rotatedSecret.Data)
+
+	err = steveclient.SteveType(stevetypes.Secret).Delete(rotatedSecretResp)
+	require.NoError(t, err)
+}
+
+// rotateV2EncryptionKeys rotates the secrets encryption keys of a RKE2/K3S cluster by increasing the rotate_encryption_keys generation
+// and waits for the rotation to be done.
+func rotateV2EncryptionKeys(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword, clusterID string, terraformOptions *terraform.Options,
+	configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	generation := terraformConfig.SecretsEncryption.RotateEncryptionKeysGeneration + 1
+
+	_, err := operations.ReplaceValue([]string{"terraform", "secretsEncryption", "rotateEncryptionKeysGeneration"}, generation, configMap[0])
+	require.NoError(t, err)
+
+	logrus.Infof("Rotating encryption keys of cluster %s with generation %d...", terraformConfig.ResourcePrefix, generation)
+	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+
+	terraform.Apply(t, terraformOptions)
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		controlPlaneResp, err := client.Steve.SteveType(stevetypes.RKEControlPlane).ByID(namespace + "/" + terraformConfig.ResourcePrefix)
+		if err != nil {
+			return false, nil
+		}
+
+		controlPlane := &rkev1.RKEControlPlane{}
+		err = steveV1.ConvertToK8sType(controlPlaneResp.JSONResp, controlPlane)
+		if err != nil {
+			return false, err
+		}
+
+		if controlPlane.Status.RotateEncryptionKeys == nil || controlPlane.Status.RotateEncryptionKeys.Generation != generation {
+			return false, nil
+		}
+
+		switch controlPlane.Status.RotateEncryptionKeysPhase {
+		case rkev1.RotateEncryptionKeysPhaseDone:
+			logrus.Infof("Encryption keys of cluster %s are rotated", terraformConfig.ResourcePrefix)
+			return true, nil
+		case rkev1.RotateEncryptionKeysPhaseFailed:
+			return false, fmt.Errorf("Encryption key rotation of cluster %s failed", terraformConfig.ResourcePrefix)
+		}
+
+		logrus.Infof("Encryption key rotation is in phase %s...", controlPlane.Status.RotateEncryptionKeysPhase)
+
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+}
+
+// rotateRKE1EncryptionKey rotates the secrets encryption key of a RKE1 cluster through the rotateEncryptionKey action of the management API.
+func rotateRKE1EncryptionKey(t *testing.T, client *rancher.Client, clusterID string) {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	logrus.Infof("Rotating encryption key of cluster %s...", cluster.Name)
+	_, err = client.Management.Cluster.ActionRotateEncryptionKey(cluster)
+	require.NoError(t, err)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+}
+
+// getEncryptionKey reads the secret from etcd on the given node and returns the name of the key it is encrypted with. K3s does not ship
+// etcdctl, so the key of K3s clusters is the active key reported by k3s secrets-encrypt, which every secret is encrypted with once a
+// rotation is done.
+func getEncryptionKey(etcdNode *nodes.Node, terraformConfig *config.TerraformConfig, secretNamespace, name string) (string, error) {
+	if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		return getK3sEncryptionKey(etcdNode)
+	}
+
+	secretKey := "/registry/secrets/" + secretNamespace + "/" + name
+
+	etcdctl := rke1Etcdctl
+	if !strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		etcdctl = fmt.Sprintf("%s exec $(%s ps --name etcd -q | head -n 1) etcdctl --cacert %s --cert %s --key %s", rke2Crictl, rke2Crictl,
+			rke2EtcdTLS+"server-ca.crt", rke2EtcdTLS+"server-client.crt", rke2EtcdTLS+"server-client.key")
+	}
+
+	output, err := etcdNode.ExecuteCommand(etcdctl + " get " + secretKey + " --print-value-only")
+	if err != nil {
+		return "", fmt.Errorf("Failed to read %s from etcd: %w", secretKey, err)
+	}
+
+	match := encryptedSecretRegex.FindStringSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("Secret %s is not encrypted in etcd", secretKey)
+	}
+
+	return match[1], nil
+}
+
+// getK3sEncryptionKey returns the name of the active secrets encryption key of the K3s server on the given node.
+func getK3sEncryptionKey(etcdNode *nodes.Node) (string, error) {
+	output, err := etcdNode.ExecuteCommand(k3sSecretsEncrypt)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read the secrets encryption status: %w", err)
+	}
+
+	if !strings.Contains(output, k3sEncryptionEnabled) {
+		return "", fmt.Errorf("Secrets encryption is not enabled: %s", output)
+	}
+
+	match := k3sActiveKeyRegex.FindStringSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("No active encryption key found: %s", output)
+	}
+
+	return match[1], nil
+}
+
+// getEtcdSSHNode returns an etcd node of the cluster with the SSH credentials Rancher provisioned it with.
+func getEtcdSSHNode(client *rancher.Client, terraformConfig *config.TerraformConfig, clusterID string) (*nodes.Node, error) {
+	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		return getRKE1EtcdSSHNode(client, clusterID)
+	}
+
+	query := url.Values{"labelSelector": {capiClusterNameLabel + "=" + terraformConfig.ResourcePrefix + "," + machineEtcdRoleLabel + "=true"}}
+
+	machines, err := client.Steve.SteveType(stevetypes.Machine).NamespacedSteveClient(namespace).List(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(machines.Data) == 0 {
+		return nil, fmt.Errorf("No etcd machines found for cluster %s", terraformConfig.ResourcePrefix)
+	}
+
+	sshKey, sshUser, sshIPAddress, err := sshkeys.DownloadSSHCredentials(client, machines.Data[0].Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodes.Node{
+		NodeID:          machines.Data[0].ID,
+		PublicIPAddress: sshIPAddress,
+		SSHUser:         sshUser,
+		SSHKey:          []byte(sshKey),
+	}, nil
+}
+
+// getRKE1EtcdSSHNode returns an etcd node of the RKE1 cluster with the SSH key downloaded from its node config.
+func getRKE1EtcdSSHNode(client *rancher.Client, clusterID string) (*nodes.Node, error) {
+	etcdNodes, err := client.Management.Node.ListAll(&types.ListOpts{
+		Filters: map[string]any{
+			"clusterId": clusterID,
+			"etcd":      true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(etcdNodes.Data) == 0 {
+		return nil, fmt.Errorf("No etcd nodes found for cluster %s", clusterID)
+	}
+
+	etcdNode := etcdNodes.Data[0]
+
+	req, err := http.NewRequest(http.MethodGet, etcdNode.Links[nodeConfigLink], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Authorization", "Bearer "+client.RancherConfig.AdminToken)
+
+	resp, err := client.Management.APIBaseClient.Ops.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(bodyBytes), int64(len(bodyBytes)))
+	if err != nil {
+		return nil, err
+	}
+
+	var sshKey []byte
+	for _, zipFile := range zipReader.File {
+		if !strings.HasSuffix(zipFile.Name, "/"+sshKeyFile) {
+			continue
+		}
+
+		rc, err := zipFile.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		sshKey, err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if sshKey == nil {
+		return nil, fmt.Errorf("No SSH key found in the node config of %s", etcdNode.NodeName)
+	}
+
+	return &nodes.Node{
+		NodeID:          etcdNode.ID,
+		PublicIPAddress: etcdNode.ExternalIPAddress,
+		SSHUser:         etcdNode.SshUser,
+		SSHKey:          sshKey,
+	}, nil
+}
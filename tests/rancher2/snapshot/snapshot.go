@@ -9,6 +9,7 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	"github.com/rancher/shepherd/clients/rancher"
 	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
 	"github.com/rancher/shepherd/extensions/clusters"
@@ -20,8 +21,10 @@ import (
 	"github.com/rancher/tests/actions/services"
 	deploy "github.com/rancher/tests/actions/workloads/deployment"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/stevetypes"
 	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,11 +49,14 @@ const (
 	localCluster        = "local"
 	kubernetesVersion   = "kubernetesVersion"
 	namespace           = "fleet-default"
+	none                = "none"
 	port                = "port"
 	postWorkload        = "wload-after-backup"
 	S3                  = "s3"
 	serviceAppendName   = "service-"
 	serviceType         = "service"
+
+	upgradedChartValues = "rke2-metrics-server:\n  podLabels:\n    tfp-automation/restore: upgraded"
 )
 
 // RestoreSnapshot creates workloads, takes a snapshot of the cluster, restores the cluster and verifies the workloads created after
@@ -58,6 +64,24 @@ const (
 func RestoreSnapshot(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	restoreSnapshot(t, client, nil, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, terraformOptions, configMap,
+		newFile, rootBody, file, false)
+}
+
+// RestoreSnapshotAfterUpgrade creates workloads, takes a snapshot of the cluster, upgrades the Kubernetes version of the cluster and
+// restores the snapshot. Depending on the restore mode, the Kubernetes version and rke config are rolled back to the ones of the snapshot.
+func RestoreSnapshotAfterUpgrade(t *testing.T, client, standardUserClient *rancher.Client, rancherConfig *rancher.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, testUser, testPassword string,
+	terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	restoreSnapshot(t, client, standardUserClient, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, terraformOptions,
+		configMap, newFile, rootBody, file, true)
+}
+
+// restoreSnapshot runs the snapshot and restore workflow, optionally upgrading the Kubernetes version of the cluster in between, and
+// verifies the Kubernetes version, rke config and workloads of the cluster match the restore mode.
+func restoreSnapshot(t *testing.T, client, standardUserClient *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, upgradeKubernetes bool) {
 	initialWorkloadName := namegen.AppendRandomString(initialWorkload)
 
 	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
@@ -71,11 +95,61 @@ func RestoreSnapshot(t *testing.T, client *rancher.Client, rancherConfig *ranche
 
 	deploymentResp, serviceResp := createWorkloads(t, client, clusterID, podTemplate, initialWorkloadName, isCattleLabeled, DeploymentSteveType)
 
+	snapshotCluster, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+
 	snapshotName, postDeploymentResp, postServiceResp, err := snapshotV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, podTemplate, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
 	require.NoError(t, err)
 
+	expectedVersion := snapshotCluster.Spec.KubernetesVersion
+	expectedRKEConfig := snapshotCluster.Spec.RKEConfig.ClusterConfiguration
+
+	// The rke2-metrics-server chart is only deployed by RKE2, so the rke config of K3s clusters is left unchanged by the upgrade.
+	changeRKEConfig := upgradeKubernetes && strings.Contains(terraformConfig.Module, clustertypes.RKE2)
+
+	if upgradeKubernetes {
+		// The rke config is changed along with the upgrade, so that only the all restore mode rolls it back.
+		if changeRKEConfig {
+			_, err = operations.ReplaceValue([]string{"terraform", "chartValues"}, upgradeChartValues(terraformConfig.ChartValues), configMap[0])
+			require.NoError(t, err)
+		}
+
+		upgradedCluster := upgradeV2Prov(t, client, standardUserClient, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
+		require.NotEqual(t, snapshotCluster.Spec.KubernetesVersion, upgradedCluster.Spec.KubernetesVersion)
+
+		if changeRKEConfig {
+			require.NotEqual(t, snapshotCluster.Spec.RKEConfig.ChartValues, upgradedCluster.Spec.RKEConfig.ChartValues)
+		}
+
+		switch terratestConfig.SnapshotInput.SnapshotRestore {
+		case none:
+			expectedVersion = upgradedCluster.Spec.KubernetesVersion
+			expectedRKEConfig = upgradedCluster.Spec.RKEConfig.ClusterConfiguration
+		case kubernetesVersion:
+			expectedRKEConfig = upgradedCluster.Spec.RKEConfig.ClusterConfiguration
+		}
+	}
+
 	restoreV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, snapshotName, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
 
+	restoredCluster, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+
+	logrus.Infof("Verifying the Kubernetes version is %s after restoring with mode %s...", expectedVersion, terratestConfig.SnapshotInput.SnapshotRestore)
+	require.Equal(t, expectedVersion, restoredCluster.Spec.KubernetesVersion)
+	require.Equal(t, expectedRKEConfig, restoredCluster.Spec.RKEConfig.ClusterConfiguration)
+
+	err = verifyKubeletVersions(client, clusterID, expectedVersion)
+	require.NoError(t, err)
+
+	if upgradeKubernetes && terratestConfig.SnapshotInput.SnapshotRestore != none {
+		renderRestoredConfig(t, client, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, expectedVersion,
+			configMap, newFile, rootBody, file)
+	}
+
+	_, err = steveclient.SteveType(DeploymentSteveType).ByID(deploymentResp.ID)
+	require.NoError(t, err)
+
 	_, err = steveclient.SteveType(DeploymentSteveType).ByID(postDeploymentResp.ID)
 	require.Error(t, err)
 
@@ -115,9 +189,78 @@ func snapshotV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher
 	snapshotID, err := getSnapshots(client, terraformConfig.ResourcePrefix)
 	require.NoError(t, err)
 
+	if terraformConfig.ETCD != nil && terraformConfig.ETCD.S3 != nil {
+		err = verifyS3Snapshot(client, terraformConfig.ResourcePrefix)
+		require.NoError(t, err)
+	}
+
 	return snapshotID[0].Name, postDeploymentResp, postServiceResp, err
 }
 
+// upgradeV2Prov upgrades the Kubernetes version of the cluster after a snapshot is taken and returns the upgraded cluster.
+func upgradeV2Prov(t *testing.T, client, standardUserClient *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, clusterID string, terraformOptions *terraform.Options,
+	configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) *provv1.Cluster {
+	_, err := operations.ReplaceValue([]string{"terratest", "snapshotInput", "createSnapshot"}, false, configMap[0])
+	require.NoError(t, err)
+
+	provisioning.KubernetesUpgrade(t, client, standardUserClient, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+
+	clusterObject, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+
+	logrus.Infof("Cluster version is upgraded to: %s", clusterObject.Spec.KubernetesVersion)
+
+	podErrors := pods.StatusPods(client, clusterID)
+	assert.Empty(t, podErrors)
+
+	return clusterObject
+}
+
+// upgradeChartValues returns the given chart values with a pod label added to the rke2-metrics-server chart, which changes the rke
+// config of the cluster without affecting its workloads.
+func upgradeChartValues(chartValues string) string {
+	if chartValues == "" {
+		return upgradedChartValues
+	}
+
+	return chartValues + "\n" + upgradedChartValues
+}
+
+// verifyKubeletVersions waits for the kubelet of every node of the cluster to run the given Kubernetes version.
+func verifyKubeletVersions(client *rancher.Client, clusterID, kubernetesVersion string) error {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Waiting for the kubelet of every node to run %s...", kubernetesVersion)
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		nodes, err := steveClient.SteveType(stevetypes.Node).List(nil)
+		if err != nil {
+			return false, nil
+		}
+
+		for _, node := range nodes.Data {
+			nodeStatus := &corev1.NodeStatus{}
+			err = steveV1.ConvertToK8sType(node.Status, nodeStatus)
+			if err != nil {
+				return false, err
+			}
+
+			if nodeStatus.NodeInfo.KubeletVersion != kubernetesVersion {
+				logrus.Infof("Node %s runs kubelet %s...", node.Name, nodeStatus.NodeInfo.KubeletVersion)
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
 // restoreV2Prov restores the cluster to the previous state after a snapshot is taken.
 func restoreV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, snapshotName, testUser, testPassword string, clusterID string, terraformOptions *terraform.Options,
@@ -148,6 +291,24 @@ func restoreV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher.
 	assert.Empty(t, podErrors)
 }
 
+// renderRestoredConfig sets the main.tf file to the Kubernetes version, and for the all restore mode the chart values, the cluster was
+// rolled back to by the restore. Otherwise, the next apply would upgrade the cluster again.
+func renderRestoredConfig(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword, restoredVersion string, configMap []map[string]any, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File) {
+	_, err := operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, restoredVersion, configMap[0])
+	require.NoError(t, err)
+
+	if terratestConfig.SnapshotInput.SnapshotRestore == all {
+		_, err = operations.ReplaceValue([]string{"terraform", "chartValues"}, terraformConfig.ChartValues, configMap[0])
+		require.NoError(t, err)
+	}
+
+	logrus.Infof("Setting main.tf to the restored Kubernetes version %s...", restoredVersion)
+	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+}
+
 // getSnapshots retrieves all snapshots for a given cluster.
 func getSnapshots(client *rancher.Client, clusterName string) ([]steveV1.SteveAPIObject, error) {
 	localclusterID, err := clusters.GetClusterIDByName(client, localCluster)
@@ -179,6 +340,25 @@ func getSnapshots(client *rancher.Client, clusterName string) ([]steveV1.SteveAP
 	return snapshots, nil
 }
 
+// verifyS3Snapshot waits for a snapshot of the given cluster to be uploaded to the configured S3 target.
+func verifyS3Snapshot(client *rancher.Client, clusterName string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		snapshots, err := getSnapshots(client, clusterName)
+		if err != nil {
+			return false, err
+		}
+
+		for _, snapshot := range snapshots {
+			if snapshot.ObjectMeta.Annotations[StorageAnnotation] == S3 {
+				logrus.Infof("Snapshot %s was uploaded to S3", snapshot.Name)
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
+
 // createWorkloads creates a deployment and service in a given cluster and verifies they are active.
 func createWorkloads(t *testing.T, client *rancher.Client, clusterID string, podTemplate corev1.PodTemplateSpec, workloadName string, isCattleLabeled bool, deploymentType string) (*steveV1.SteveAPIObject, *steveV1.SteveAPIObject) {
 	deployment := workloads.NewDeploymentTemplate(workloadName, defaultNamespace, podTemplate, isCattleLabeled, nil)
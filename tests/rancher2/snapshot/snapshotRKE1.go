@@ -0,0 +1,141 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/etcdsnapshot"
+	"github.com/rancher/shepherd/extensions/workloads"
+	"github.com/rancher/shepherd/extensions/workloads/pods"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const rke1VersionSuffix = "-rancher"
+
+// RestoreSnapshotRKE1 creates workloads, takes a snapshot of the RKE1 cluster through the etcdBackups action, restores it through the
+// restoreFromEtcdBackup action and verifies the workloads created after the snapshot are no longer present in the cluster.
+func RestoreSnapshotRKE1(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) {
+	clusterName := terraformConfig.ResourcePrefix
+	initialWorkloadName := namegen.AppendRandomString(initialWorkload)
+
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	containerTemplate := workloads.NewContainer(containerName, containerImage, corev1.PullAlways, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+
+	deploymentResp, serviceResp := createWorkloads(t, client, clusterID, podTemplate, initialWorkloadName, isCattleLabeled, DeploymentSteveType)
+
+	snapshotCluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	snapshots, err := etcdsnapshot.CreateRKE1Snapshot(client, clusterName)
+	require.NoError(t, err)
+
+	snapshot := latestManualRKE1Snapshot(t, snapshots)
+	verifyRKE1Snapshot(t, terraformConfig, snapshot)
+
+	postWorkloadName := namegen.AppendRandomString(postWorkload)
+	postDeploymentResp, postServiceResp := createWorkloads(t, client, clusterID, podTemplate, postWorkloadName, isCattleLabeled, DeploymentSteveType)
+
+	snapshotRestore := &management.RestoreFromEtcdBackupInput{
+		EtcdBackupID:     snapshot.ID,
+		RestoreRkeConfig: rke1RestoreMode(terratestConfig.SnapshotInput.SnapshotRestore),
+	}
+
+	err = etcdsnapshot.RestoreRKE1Snapshot(client, clusterName, snapshotRestore)
+	require.NoError(t, err)
+
+	restoredCluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	expectedVersion := snapshotCluster.RancherKubernetesEngineConfig.Version
+
+	logrus.Infof("Verifying the Kubernetes version is %s after restoring with mode %s...", expectedVersion, terratestConfig.SnapshotInput.SnapshotRestore)
+	require.Equal(t, expectedVersion, restoredCluster.RancherKubernetesEngineConfig.Version)
+
+	if terratestConfig.SnapshotInput.SnapshotRestore == all {
+		require.Equal(t, snapshotCluster.RancherKubernetesEngineConfig, restoredCluster.RancherKubernetesEngineConfig)
+	}
+
+	// RKE1 versions carry a Rancher suffix, e.g. v1.28.10-rancher1-1, that the kubelet does not report.
+	kubeletVersion, _, _ := strings.Cut(expectedVersion, rke1VersionSuffix)
+	err = verifyKubeletVersions(client, clusterID, kubeletVersion)
+	require.NoError(t, err)
+
+	podErrors := pods.StatusPods(client, clusterID)
+	assert.Empty(t, podErrors)
+
+	_, err = steveclient.SteveType(DeploymentSteveType).ByID(deploymentResp.ID)
+	require.NoError(t, err)
+
+	_, err = steveclient.SteveType(DeploymentSteveType).ByID(postDeploymentResp.ID)
+	require.Error(t, err)
+
+	_, err = steveclient.SteveType(serviceType).ByID(postServiceResp.ID)
+	require.Error(t, err)
+
+	logrus.Infof("Deleting created workloads...")
+	err = steveclient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	require.NoError(t, err)
+
+	err = steveclient.SteveType(stevetypes.Service).Delete(serviceResp)
+	require.NoError(t, err)
+}
+
+// latestManualRKE1Snapshot returns the newest manual snapshot of the given snapshots, which is the one taken through the etcdBackups
+// action. Recurring snapshots taken in the meantime are ignored.
+func latestManualRKE1Snapshot(t *testing.T, snapshots []management.EtcdBackup) management.EtcdBackup {
+	var manualSnapshots []management.EtcdBackup
+	for _, snapshot := range snapshots {
+		if snapshot.Manual {
+			manualSnapshots = append(manualSnapshots, snapshot)
+		}
+	}
+
+	require.NotEmpty(t, manualSnapshots, "No manual snapshot was taken")
+
+	sort.Slice(manualSnapshots, func(i, j int) bool {
+		return manualSnapshots[i].Created < manualSnapshots[j].Created
+	})
+
+	return manualSnapshots[len(manualSnapshots)-1]
+}
+
+// verifyRKE1Snapshot verifies the manual snapshot of the RKE1 cluster is active, and uploaded to S3 when a backup target is configured.
+func verifyRKE1Snapshot(t *testing.T, terraformConfig *config.TerraformConfig, snapshot management.EtcdBackup) {
+	s3Enabled := terraformConfig.ETCDRKE1 != nil && terraformConfig.ETCDRKE1.BackupConfig != nil &&
+		terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig != nil
+
+	logrus.Infof("Verifying snapshot %s...", snapshot.Name)
+	require.Equal(t, active, snapshot.State)
+	require.NotEmpty(t, snapshot.Filename)
+
+	if s3Enabled {
+		require.NotNil(t, snapshot.BackupConfig)
+		require.NotNil(t, snapshot.BackupConfig.S3BackupConfig)
+		require.Equal(t, terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.BucketName, snapshot.BackupConfig.S3BackupConfig.BucketName)
+	}
+}
+
+// rke1RestoreMode returns the RKE1 restoreRkeConfig value of the given restore mode. RKE1 restores only the etcd contents when it is empty.
+func rke1RestoreMode(snapshotRestore string) string {
+	if snapshotRestore == none {
+		return ""
+	}
+
+	return snapshotRestore
+}
@@ -93,6 +93,67 @@ func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestore() {
 
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
 
+		s.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(s.T(), s.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
+			require.NoError(s.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+
+			if strings.Contains(terraform.Module, clustertypes.RKE1) {
+				RestoreSnapshotRKE1(s.T(), adminClient, terraform, terratest)
+			} else {
+				RestoreSnapshot(s.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file)
+			}
+
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+		})
+	}
+
+	if s.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(s.terratestConfig)
+	}
+}
+
+func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestoreUpgrade() {
+	var err error
+	var testUser, testPassword string
+
+	s.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(s.client)
+	require.NoError(s.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name            string
+		nodeRoles       []config.Nodepool
+		snapshotRestore string
+	}{
+		{"Snapshot_Restore_ETCD_Only_Upgrade", nodeRolesDedicated, none},
+		{"Snapshot_Restore_K8s_Version_Upgrade", nodeRolesDedicated, kubernetesVersion},
+		{"Snapshot_Restore_All_Upgrade", nodeRolesDedicated, all},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(s.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "snapshotInput", "snapshotRestore"}, tt.snapshotRestore, configMap[0])
+		require.NoError(s.T(), err)
+
+		provisioning.GetK8sVersion(s.T(), s.client, s.terratestConfig, s.terraformConfig, configs.SecondHighestVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
 		if strings.Contains(s.terraformConfig.Module, clustertypes.RKE1) {
 			s.T().Skip("RKE1 is not supported")
 		}
@@ -107,7 +168,7 @@ func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestore() {
 			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
 
-			RestoreSnapshot(s.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file)
+			RestoreSnapshotAfterUpgrade(s.T(), adminClient, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file)
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
 		})
 	}
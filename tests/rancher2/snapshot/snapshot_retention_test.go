@@ -0,0 +1,138 @@
+package snapshot
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	retentionCron  = "*/3 * * * *"
+	retentionCount = 2
+)
+
+type SnapshotRetentionTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (s *SnapshotRetentionTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	s.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(s.T(), err)
+
+	s.client = client
+
+	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	s.rancherConfig, s.terraformConfig, s.terratestConfig, _ = config.LoadTFPConfigs(s.cattleConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(s.T(), s.terraformConfig, s.terratestConfig, keyPath)
+	s.terraformOptions = terraformOptions
+}
+
+func (s *SnapshotRetentionTestSuite) TestTfpSnapshotRetention() {
+	var err error
+	var testUser, testPassword string
+
+	s.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(s.client)
+	require.NoError(s.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		nodeRoles []config.Nodepool
+	}{
+		{"Snapshot_Retention", nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(s.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "etcd", "snapshotScheduleCron"}, retentionCron, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "etcd", "snapshotRetention"}, retentionCount, configMap[0])
+		require.NoError(s.T(), err)
+
+		provisioning.GetK8sVersion(s.T(), s.client, s.terratestConfig, s.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		if strings.Contains(s.terraformConfig.Module, clustertypes.RKE1) {
+			s.T().Skip("RKE1 is not supported")
+		}
+
+		if terraform.ETCD == nil {
+			s.T().Skip("The etcd block must be set to verify snapshot retention")
+		}
+
+		s.Run(tt.name, func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(s.T(), s.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
+			require.NoError(s.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+
+			VerifySnapshotRetention(s.T(), adminClient, terraform)
+
+			// Only the machines of node driver clusters are replaced by Rancher once they are deleted.
+			if strings.Contains(terraform.Module, defaults.Custom) || strings.Contains(terraform.Module, defaults.Airgap) ||
+				strings.Contains(terraform.Module, defaults.Import) {
+				logrus.Infof("Skipping snapshot reconciliation, etcd machines are not replaced for module: %v", terraform.Module)
+				return
+			}
+
+			VerifySnapshotsReconciled(s.T(), adminClient, terraform)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+		})
+	}
+
+	if s.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(s.terratestConfig)
+	}
+}
+
+func TestTfpSnapshotRetentionTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotRetentionTestSuite))
+}
@@ -0,0 +1,449 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	capiClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+	etcdRoleLabel        = "node-role.kubernetes.io/etcd"
+	machineEtcdRoleLabel = "rke.cattle.io/etcd-role"
+	defaultS3Region      = "us-east-1"
+	httpsPrefix          = "https://"
+	metadataFolder       = ".metadata"
+	s3Location           = "s3://"
+	s3StorageSuffix      = "-s3"
+	scheduledSnapshot    = "etcd-snapshot-"
+
+	cloudCredentialNamespace = "cattle-global-data"
+	accessKeySuffix          = "credentialConfig-accessKey"
+	secretKeySuffix          = "credentialConfig-secretKey"
+)
+
+// VerifySnapshotRetention waits for the snapshot_schedule_cron to produce scheduled snapshots on every etcd node and validates that
+// snapshot_retention pruned the older ones. When an S3 target is configured, the S3 snapshots and objects are verified as well.
+func VerifySnapshotRetention(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterName := terraformConfig.ResourcePrefix
+	retention := terraformConfig.ETCD.SnapshotRetention
+	s3Enabled := terraformConfig.ETCD.S3 != nil
+
+	etcdNodes, err := getEtcdNodes(client, clusterName)
+	require.NoError(t, err)
+
+	seen := map[string]bool{}
+
+	logrus.Infof("Waiting for %v scheduled snapshots to be retained on each of the %v etcd nodes...", retention, len(etcdNodes))
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		snapshots, err := listETCDSnapshots(client, clusterName)
+		if err != nil {
+			return false, nil
+		}
+
+		local, remote := splitByStorage(scheduledSnapshots(snapshots))
+		for _, snapshot := range local {
+			seen[snapshot.SnapshotFile.Name] = true
+		}
+
+		// Pruning can only be confirmed once more snapshots have been taken than can be retained.
+		if len(seen) <= retention*len(etcdNodes) {
+			return false, nil
+		}
+
+		localCount := countByNode(local)
+		for _, node := range etcdNodes {
+			if localCount[node] != retention {
+				logrus.Infof("Node %s has %v local scheduled snapshots, expecting %v...", node, localCount[node], retention)
+				return false, nil
+			}
+		}
+
+		if s3Enabled && len(remote) != retention*len(etcdNodes) {
+			logrus.Infof("Found %v S3 scheduled snapshots, expecting %v...", len(remote), retention*len(etcdNodes))
+			return false, nil
+		}
+
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	if s3Enabled {
+		verifyLocalAndS3Agree(t, client, clusterName)
+		verifyS3Objects(t, client, terraformConfig)
+	}
+}
+
+// VerifySnapshotsReconciled deletes an etcd machine of the cluster that has local snapshots and validates that once it is replaced,
+// no local snapshot references a node that no longer exists. S3 snapshots outlive the node, so they are expected to remain.
+func VerifySnapshotsReconciled(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterName := terraformConfig.ResourcePrefix
+
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	query := url.Values{"labelSelector": {capiClusterNameLabel + "=" + clusterName + "," + machineEtcdRoleLabel + "=true"}}
+
+	machines, err := client.Steve.SteveType(stevetypes.Machine).NamespacedSteveClient(namespace).List(query)
+	require.NoError(t, err)
+	require.NotEmpty(t, machines.Data)
+
+	snapshots, err := listETCDSnapshots(client, clusterName)
+	require.NoError(t, err)
+
+	local, remote := splitByStorage(snapshots)
+	localCount := countByNode(local)
+	s3Snapshots := snapshotNames(remote)
+
+	if terraformConfig.ETCD.S3 != nil {
+		require.NotEmpty(t, s3Snapshots)
+	}
+
+	var deletedMachine *steveV1.SteveAPIObject
+	var deletedNode string
+
+	for _, machine := range machines.Data {
+		machineStatus := struct {
+			NodeRef struct {
+				Name string `json:"name"`
+			} `json:"nodeRef"`
+		}{}
+
+		err = steveV1.ConvertToK8sType(machine.Status, &machineStatus)
+		require.NoError(t, err)
+
+		if localCount[machineStatus.NodeRef.Name] > 0 {
+			deletedMachine = &machine
+			deletedNode = machineStatus.NodeRef.Name
+			break
+		}
+	}
+
+	require.NotNilf(t, deletedMachine, "No etcd machine of cluster %s has local snapshots: %v", clusterName, localCount)
+
+	logrus.Infof("Deleting etcd machine %s of node %s with %v local snapshots...", deletedMachine.Name, deletedNode, localCount[deletedNode])
+	err = client.Steve.SteveType(stevetypes.Machine).Delete(deletedMachine)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for etcd machine %s to be replaced...", deletedMachine.Name)
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		currentMachines, err := client.Steve.SteveType(stevetypes.Machine).NamespacedSteveClient(namespace).List(query)
+		if err != nil {
+			return false, nil
+		}
+
+		for _, machine := range currentMachines.Data {
+			if machine.Name == deletedMachine.Name {
+				return false, nil
+			}
+		}
+
+		return len(currentMachines.Data) >= len(machines.Data), nil
+	})
+	require.NoError(t, err)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+
+	logrus.Infof("Waiting for local snapshots of removed nodes to be reconciled...")
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FifteenMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		etcdNodes, err := getEtcdNodes(client, clusterName)
+		if err != nil {
+			return false, nil
+		}
+
+		snapshots, err := listETCDSnapshots(client, clusterName)
+		if err != nil {
+			return false, nil
+		}
+
+		local, _ := splitByStorage(snapshots)
+		for node := range countByNode(local) {
+			if !slices.Contains(etcdNodes, node) {
+				logrus.Infof("Waiting for local snapshots of removed node %s to be reconciled...", node)
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	snapshots, err = listETCDSnapshots(client, clusterName)
+	require.NoError(t, err)
+
+	_, remote = splitByStorage(snapshots)
+	remainingS3Snapshots := snapshotNames(remote)
+
+	for name := range s3Snapshots {
+		require.Truef(t, remainingS3Snapshots[name], "S3 snapshot %s was removed along with node %s", name, deletedNode)
+	}
+}
+
+// verifyLocalAndS3Agree validates that every scheduled snapshot taken locally was also uploaded to S3, and vice versa.
+func verifyLocalAndS3Agree(t *testing.T, client *rancher.Client, clusterName string) {
+	var missing []string
+
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.TenSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		snapshots, err := listETCDSnapshots(client, clusterName)
+		if err != nil {
+			return false, nil
+		}
+
+		local, remote := splitByStorage(scheduledSnapshots(snapshots))
+
+		localNames := snapshotNames(local)
+		remoteNames := snapshotNames(remote)
+
+		missing = nil
+		for name := range localNames {
+			if !remoteNames[name] {
+				missing = append(missing, name)
+			}
+		}
+
+		for name := range remoteNames {
+			if !localNames[name] {
+				missing = append(missing, name)
+			}
+		}
+
+		return len(missing) == 0, nil
+	})
+	require.NoErrorf(t, err, "Local and S3 snapshots do not agree, snapshots only stored in one location: %v", missing)
+}
+
+// verifyS3Objects validates that the number of snapshot objects stored in the S3 bucket matches the S3 snapshots known to Rancher.
+func verifyS3Objects(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	s3Client, err := newS3Client(client, terraformConfig)
+	require.NoError(t, err)
+
+	snapshots, err := listETCDSnapshots(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	_, remote := splitByStorage(snapshots)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(terraformConfig.ETCD.S3.Bucket),
+		Prefix: aws.String(terraformConfig.ETCD.S3.Folder),
+	}
+
+	var objects []string
+
+	err = s3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			key := aws.StringValue(object.Key)
+			if strings.Contains(key, terraformConfig.ResourcePrefix) && !strings.Contains(key, metadataFolder) {
+				objects = append(objects, key)
+			}
+		}
+
+		return true
+	})
+	require.NoError(t, err)
+
+	logrus.Infof("Found %v snapshot objects in bucket %s", len(objects), terraformConfig.ETCD.S3.Bucket)
+	require.Equalf(t, len(remote), len(objects), "S3 snapshots in Rancher do not match the objects in bucket %s: %v",
+		terraformConfig.ETCD.S3.Bucket, objects)
+}
+
+// newS3Client returns an S3 client for the etcd S3 target. The keys are taken from the same credentials the snapshots are uploaded
+// with: the existing cloud credential, the etcd S3 credentials or the AWS credentials of EC2 clusters.
+func newS3Client(client *rancher.Client, terraformConfig *config.TerraformConfig) (*s3.S3, error) {
+	accessKey, secretKey := terraformConfig.AWSCredentials.AWSAccessKey, terraformConfig.AWSCredentials.AWSSecretKey
+	if terraformConfig.ETCD.S3.CloudCredentialName != "" {
+		var err error
+
+		accessKey, secretKey, err = cloudCredentialKeys(client, terraformConfig.ETCD.S3.CloudCredentialName)
+		if err != nil {
+			return nil, err
+		}
+	} else if terraformConfig.ETCDS3Credentials != nil {
+		accessKey, secretKey = terraformConfig.ETCDS3Credentials.AccessKey, terraformConfig.ETCDS3Credentials.SecretKey
+	}
+
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("S3 credentials must be set to list objects in bucket: %v", terraformConfig.ETCD.S3.Bucket)
+	}
+
+	region := terraformConfig.ETCD.S3.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	endpoint := terraformConfig.ETCD.S3.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = httpsPrefix + endpoint
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: terraformConfig.ETCD.S3.SkipSSLVerify},
+		},
+	}
+
+	s3Session, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		Endpoint:         aws.String(endpoint),
+		HTTPClient:       httpClient,
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.New(s3Session), nil
+}
+
+// listETCDSnapshots returns the etcd snapshots of the given cluster.
+func listETCDSnapshots(client *rancher.Client, clusterName string) ([]rkev1.ETCDSnapshot, error) {
+	snapshotObjects, err := getSnapshots(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []rkev1.ETCDSnapshot
+	for _, snapshotObject := range snapshotObjects {
+		snapshot := rkev1.ETCDSnapshot{}
+		err = steveV1.ConvertToK8sType(snapshotObject.JSONResp, &snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		if snapshot.Spec.ClusterName == clusterName {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	return snapshots, nil
+}
+
+// getEtcdNodes returns the names of the etcd nodes of the given cluster.
+func getEtcdNodes(client *rancher.Client, clusterName string) ([]string, error) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := steveClient.SteveType(stevetypes.Node).List(url.Values{"labelSelector": {etcdRoleLabel + "=true"}})
+	if err != nil {
+		return nil, err
+	}
+
+	var etcdNodes []string
+	for _, node := range nodes.Data {
+		etcdNodes = append(etcdNodes, node.Name)
+	}
+
+	return etcdNodes, nil
+}
+
+// scheduledSnapshots returns the snapshots that were taken by the snapshot schedule, leaving out on-demand snapshots.
+func scheduledSnapshots(snapshots []rkev1.ETCDSnapshot) []rkev1.ETCDSnapshot {
+	var scheduled []rkev1.ETCDSnapshot
+	for _, snapshot := range snapshots {
+		if strings.Contains(snapshot.SnapshotFile.Name, scheduledSnapshot) {
+			scheduled = append(scheduled, snapshot)
+		}
+	}
+
+	return scheduled
+}
+
+// splitByStorage splits the snapshots into the ones stored locally on the etcd nodes and the ones stored in S3.
+func splitByStorage(snapshots []rkev1.ETCDSnapshot) ([]rkev1.ETCDSnapshot, []rkev1.ETCDSnapshot) {
+	var local, remote []rkev1.ETCDSnapshot
+	for _, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.SnapshotFile.Location, s3Location) {
+			remote = append(remote, snapshot)
+		} else {
+			local = append(local, snapshot)
+		}
+	}
+
+	return local, remote
+}
+
+// countByNode returns the number of snapshots taken on each node.
+func countByNode(snapshots []rkev1.ETCDSnapshot) map[string]int {
+	counts := map[string]int{}
+	for _, snapshot := range snapshots {
+		counts[snapshot.SnapshotFile.NodeName]++
+	}
+
+	return counts
+}
+
+// snapshotNames returns the snapshot names without the storage suffix, so local and S3 copies of a snapshot share a name.
+func snapshotNames(snapshots []rkev1.ETCDSnapshot) map[string]bool {
+	names := map[string]bool{}
+	for _, snapshot := range snapshots {
+		names[strings.TrimSuffix(snapshot.SnapshotFile.Name, s3StorageSuffix)] = true
+	}
+
+	return names
+}
+
+// cloudCredentialKeys returns the access and secret keys stored in the secret of a cloud credential. The cloud credential name is
+// its ID, e.g. cattle-global-data:cc-abcde, and the keys are stored under the credential config of its driver, e.g. s3credentialConfig.
+func cloudCredentialKeys(client *rancher.Client, cloudCredentialName string) (string, string, error) {
+	namespace, name, found := strings.Cut(cloudCredentialName, ":")
+	if !found {
+		namespace, name = cloudCredentialNamespace, cloudCredentialName
+	}
+
+	secretResp, err := client.Steve.SteveType(stevetypes.Secret).ByID(namespace + "/" + name)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret := &corev1.Secret{}
+	err = steveV1.ConvertToK8sType(secretResp.JSONResp, secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	var accessKey, secretKey string
+	for key, value := range secret.Data {
+		if strings.HasSuffix(key, accessKeySuffix) {
+			accessKey = string(value)
+		}
+
+		if strings.HasSuffix(key, secretKeySuffix) {
+			secretKey = string(value)
+		}
+	}
+
+	if accessKey == "" || secretKey == "" {
+		return "", "", fmt.Errorf("Cloud credential %v does not hold an access and secret key", cloudCredentialName)
+	}
+
+	return accessKey, secretKey, nil
+}
@@ -1,6 +1,7 @@
 package upgrading
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -126,6 +127,106 @@ func (k *KubernetesUpgradeHostedTestSuite) TestTfpKubernetesUpgradeHosted() {
 	}
 }
 
+func (k *KubernetesUpgradeHostedTestSuite) TestTfpKubernetesUpgradeHostedSequence() {
+	var err error
+	var testUser, testPassword string
+
+	k.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(k.client)
+	require.NoError(k.T(), err)
+
+	aksNodePools := []config.Nodepool{{Quantity: 2}, {Quantity: 1}}
+	eksNodePools := []config.Nodepool{
+		{DiskSize: 100, InstanceType: k.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2},
+		{DiskSize: 100, InstanceType: k.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2},
+	}
+	gkeNodePools := []config.Nodepool{{Quantity: 2, MaxPodsConstraint: 110}, {Quantity: 1, MaxPodsConstraint: 110}}
+
+	tests := []struct {
+		name                      string
+		module                    string
+		nodePools                 []config.Nodepool
+		kubernetesVersion         string
+		upgradedKubernetesVersion string
+	}{
+		{"Upgrade_AKS_Cluster_Sequence", modules.AKS, aksNodePools, k.terratestConfig.AKSKubernetesVersion, k.terratestConfig.UpgradedAKSKubernetesVersion},
+		{"Upgrade_EKS_Cluster_Sequence", modules.EKS, eksNodePools, k.terratestConfig.EKSKubernetesVersion, k.terratestConfig.UpgradedEKSKubernetesVersion},
+		{"Upgrade_GKE_Cluster_Sequence", modules.GKE, gkeNodePools, k.terratestConfig.GKEKubernetesVersion, k.terratestConfig.UpgradedGKEKubernetesVersion},
+	}
+
+	for _, tt := range tests {
+		newFile, rootBody, file := rancher2.InitializeMainTF(k.terratestConfig)
+		defer file.Close()
+
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{k.cattleConfig})
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodePools, configMap[0])
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, tt.kubernetesVersion, configMap[0])
+		require.NoError(k.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "upgradedKubernetesVersion"}, tt.upgradedKubernetesVersion, configMap[0])
+		require.NoError(k.T(), err)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		k.Run((tt.name), func() {
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, k.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(k.T(), k.terraformOptions, keyPath)
+
+			adminClient, err := provisioning.FetchAdminClient(k.T(), k.client)
+			require.NoError(k.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(k.T(), k.client, k.standardUserClient, rancher, terraform, terratest, testUser, testPassword, k.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(k.T(), adminClient, clusterIDs)
+
+			logrus.Infof("Upgrading node pool 0 past the version of the control plane...")
+			err = provisioning.HostedNodePoolVersionUpgrade(k.T(), k.client, rancher, testUser, testPassword, k.terraformOptions, configMap, newFile, rootBody, file, 0, tt.upgradedKubernetesVersion)
+
+			var versionSkewError *provisioning.VersionSkewError
+			require.True(k.T(), errors.As(err, &versionSkewError), "Expected a version skew error, got: %v", err)
+			require.Equal(k.T(), tt.upgradedKubernetesVersion, versionSkewError.NodePoolVersion)
+
+			_, terraform, terratest, _ = config.LoadTFPConfigs(configMap[0])
+			require.Equal(k.T(), tt.nodePools[0].KubernetesVersion, terratest.Nodepools[0].KubernetesVersion)
+
+			provisioning.VerifyHostedNodePoolVersions(k.T(), adminClient, clusterIDs[0], terraform, terratest)
+
+			err = provisioning.HostedControlPlaneUpgrade(k.T(), k.client, rancher, testUser, testPassword, k.terraformOptions, configMap, newFile, rootBody, file)
+			require.NoError(k.T(), err)
+
+			provisioning.VerifyHostedControlPlaneVersion(k.T(), adminClient, clusterIDs[0], tt.upgradedKubernetesVersion)
+
+			_, terraform, terratest, _ = config.LoadTFPConfigs(configMap[0])
+			provisioning.VerifyHostedNodePoolVersions(k.T(), adminClient, clusterIDs[0], terraform, terratest)
+
+			for i := range tt.nodePools {
+				err = provisioning.HostedNodePoolUpgrade(k.T(), k.client, rancher, testUser, testPassword, k.terraformOptions, configMap, newFile, rootBody, file, i)
+				require.NoError(k.T(), err)
+
+				_, terraform, terratest, _ = config.LoadTFPConfigs(configMap[0])
+				provisioning.VerifyHostedNodePoolVersions(k.T(), adminClient, clusterIDs[0], terraform, terratest)
+			}
+
+			provisioning.VerifyClustersState(k.T(), adminClient, clusterIDs)
+		})
+
+		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+		err = qase.UpdateSchemaParameters(tt.name, params)
+		if err != nil {
+			logrus.Warningf("Failed to upload schema parameters %s", err)
+		}
+	}
+
+	if k.terratestConfig.LocalQaseReporting {
+		results.ReportTest(k.terratestConfig)
+	}
+}
+
 func TestTfpKubernetesUpgradeHostedTestSuite(t *testing.T) {
 	suite.Run(t, new(KubernetesUpgradeHostedTestSuite))
 }